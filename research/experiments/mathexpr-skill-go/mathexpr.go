@@ -2,6 +2,7 @@ package mathexpr
 
 import (
 	"fmt"
+	"maps"
 	"math"
 	"strconv"
 	"strings"
@@ -22,6 +23,8 @@ const (
 	TokenPower   TokenKind = "power"
 	TokenLParen  TokenKind = "lparen"
 	TokenRParen  TokenKind = "rparen"
+	TokenIdent   TokenKind = "ident"
+	TokenComma   TokenKind = "comma"
 )
 
 // Token represents a lexical token with a kind and string value.
@@ -66,6 +69,21 @@ type BinaryExpr struct {
 
 func (BinaryExpr) astNode() {}
 
+// VariableRef represents a named variable resolved at evaluation time.
+type VariableRef struct {
+	Name string
+}
+
+func (VariableRef) astNode() {}
+
+// FunctionCall represents a call to a named function (e.g., sqrt(2)).
+type FunctionCall struct {
+	Name string
+	Args []AstNode
+}
+
+func (FunctionCall) astNode() {}
+
 // --- tokenizer ---
 
 // Tokenize converts a math expression string into a sequence of tokens.
@@ -98,6 +116,16 @@ func Tokenize(input string) ([]Token, error) {
 			continue
 		}
 
+		// Identifiers: letter or underscore, then letters, digits, underscores
+		if isIdentStart(ch) {
+			start := i
+			for i < len(input) && (isIdentStart(input[i]) || (input[i] >= '0' && input[i] <= '9')) {
+				i++
+			}
+			tokens = append(tokens, NewToken(TokenIdent, input[start:i]))
+			continue
+		}
+
 		// ** (power) — must check before single *
 		if ch == '*' && i+1 < len(input) && input[i+1] == '*' {
 			tokens = append(tokens, NewToken(TokenPower, "**"))
@@ -121,6 +149,8 @@ func Tokenize(input string) ([]Token, error) {
 			tokens = append(tokens, NewToken(TokenLParen, "("))
 		case ')':
 			tokens = append(tokens, NewToken(TokenRParen, ")"))
+		case ',':
+			tokens = append(tokens, NewToken(TokenComma, ","))
 		default:
 			return nil, fmt.Errorf("Unexpected character '%c' at position %d", ch, i)
 		}
@@ -129,6 +159,10 @@ func Tokenize(input string) ([]Token, error) {
 	return tokens, nil
 }
 
+func isIdentStart(ch byte) bool {
+	return (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') || ch == '_'
+}
+
 // --- parser ---
 
 type parser struct {
@@ -235,7 +269,8 @@ func (p *parser) parseUnary() (AstNode, error) {
 	return p.parseAtom()
 }
 
-// parseAtom handles numbers and parenthesized expressions (precedence level 5).
+// parseAtom handles numbers, variables, function calls, and parenthesized
+// expressions (precedence level 5).
 func (p *parser) parseAtom() (AstNode, error) {
 	tok := p.peek()
 	if tok == nil {
@@ -249,6 +284,12 @@ func (p *parser) parseAtom() (AstNode, error) {
 			return nil, fmt.Errorf("Invalid number: %s", t.Value)
 		}
 		return NumberLiteral{Value: val}, nil
+	case TokenIdent:
+		t := p.advance()
+		if next := p.peek(); next != nil && next.Kind == TokenLParen {
+			return p.parseCall(t.Value)
+		}
+		return VariableRef{Name: t.Value}, nil
 	case TokenLParen:
 		p.advance() // consume '('
 		expr, err := p.parseAddSub()
@@ -265,6 +306,33 @@ func (p *parser) parseAtom() (AstNode, error) {
 	}
 }
 
+// parseCall handles the parenthesized, comma-separated arguments of a call
+// to name.
+func (p *parser) parseCall(name string) (AstNode, error) {
+	p.advance() // consume '('
+	args := []AstNode{}
+	if tok := p.peek(); tok != nil && tok.Kind == TokenRParen {
+		p.advance()
+		return FunctionCall{Name: name, Args: args}, nil
+	}
+	for {
+		arg, err := p.parseAddSub()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+		tok := p.peek()
+		if tok == nil || tok.Kind != TokenComma {
+			break
+		}
+		p.advance() // consume ','
+	}
+	if _, err := p.expect(TokenRParen); err != nil {
+		return nil, err
+	}
+	return FunctionCall{Name: name, Args: args}, nil
+}
+
 // Parse converts a slice of tokens into an AST.
 func Parse(tokens []Token) (AstNode, error) {
 	if len(tokens) == 0 {
@@ -283,23 +351,122 @@ func Parse(tokens []Token) (AstNode, error) {
 
 // --- evaluator ---
 
-// Evaluate walks an AST and computes the numeric result.
+// constants holds the built-in named values.
+var constants = map[string]float64{
+	"pi":  math.Pi,
+	"e":   math.E,
+	"tau": 2 * math.Pi,
+}
+
+// FuncRegistry maps function names to their implementations. Each function
+// receives its evaluated arguments and reports its own arity errors.
+type FuncRegistry map[string]func([]float64) (float64, error)
+
+// DefaultFuncs returns a fresh copy of the built-in functions, which callers
+// may extend and pass to EvaluateWithFuncs.
+func DefaultFuncs() FuncRegistry {
+	return maps.Clone(builtins)
+}
+
+// builtins holds the functions available to Evaluate.
+var builtins = FuncRegistry{
+	"sin":  unary("sin", math.Sin),
+	"cos":  unary("cos", math.Cos),
+	"tan":  unary("tan", math.Tan),
+	"sqrt": unary("sqrt", math.Sqrt),
+	"abs":  unary("abs", math.Abs),
+	"ln":   unary("ln", math.Log),
+	"log":  unary("log", math.Log10),
+	"exp":  unary("exp", math.Exp),
+	"min":  variadic("min", math.Min),
+	"max":  variadic("max", math.Max),
+}
+
+// unary adapts a one-argument math function into a builtin.
+func unary(name string, f func(float64) float64) func([]float64) (float64, error) {
+	return func(args []float64) (float64, error) {
+		if len(args) != 1 {
+			return 0, fmt.Errorf("wrong number of arguments to %s", name)
+		}
+		return f(args[0]), nil
+	}
+}
+
+// variadic adapts a two-argument math function into a builtin that folds it
+// over one or more arguments.
+func variadic(name string, f func(float64, float64) float64) func([]float64) (float64, error) {
+	return func(args []float64) (float64, error) {
+		if len(args) == 0 {
+			return 0, fmt.Errorf("wrong number of arguments to %s", name)
+		}
+		result := args[0]
+		for _, arg := range args[1:] {
+			result = f(result, arg)
+		}
+		return result, nil
+	}
+}
+
+// Evaluate walks an AST and computes the numeric result. The constants pi,
+// e, and tau and the functions sin, cos, tan, sqrt, abs, ln (natural log),
+// log (base 10), exp, min, and max are available.
 func Evaluate(node AstNode) (float64, error) {
+	return EvaluateWithEnv(node, nil)
+}
+
+// EvaluateWithEnv is Evaluate with variables resolved from env. A name is
+// looked up in env first and then among the built-in constants (pi, e, tau),
+// so an env entry named "pi" overrides the built-in.
+func EvaluateWithEnv(node AstNode, env map[string]float64) (float64, error) {
+	return evaluate(node, env, builtins)
+}
+
+// EvaluateWithFuncs is Evaluate with function calls dispatched to funcs,
+// which replaces the built-in functions. Start from DefaultFuncs to extend
+// them rather than replace them.
+func EvaluateWithFuncs(node AstNode, funcs FuncRegistry) (float64, error) {
+	return evaluate(node, nil, funcs)
+}
+
+// evaluate implements the Evaluate variants.
+func evaluate(node AstNode, env map[string]float64, funcs FuncRegistry) (float64, error) {
 	switch n := node.(type) {
 	case NumberLiteral:
 		return n.Value, nil
+	case VariableRef:
+		if val, ok := env[n.Name]; ok {
+			return val, nil
+		}
+		if val, ok := constants[n.Name]; ok {
+			return val, nil
+		}
+		return 0, fmt.Errorf("undefined variable: %s", n.Name)
+	case FunctionCall:
+		fn, ok := funcs[n.Name]
+		if !ok {
+			return 0, fmt.Errorf("unknown function: %s", n.Name)
+		}
+		args := make([]float64, len(n.Args))
+		for i, arg := range n.Args {
+			val, err := evaluate(arg, env, funcs)
+			if err != nil {
+				return 0, err
+			}
+			args[i] = val
+		}
+		return fn(args)
 	case UnaryExpr:
-		operand, err := Evaluate(n.Operand)
+		operand, err := evaluate(n.Operand, env, funcs)
 		if err != nil {
 			return 0, err
 		}
 		return -operand, nil
 	case BinaryExpr:
-		left, err := Evaluate(n.Left)
+		left, err := evaluate(n.Left, env, funcs)
 		if err != nil {
 			return 0, err
 		}
-		right, err := Evaluate(n.Right)
+		right, err := evaluate(n.Right, env, funcs)
 		if err != nil {
 			return 0, err
 		}
@@ -334,6 +501,12 @@ func Evaluate(node AstNode) (float64, error) {
 
 // Calc evaluates a math expression string and returns the numeric result.
 func Calc(expression string) (float64, error) {
+	return CalcWithEnv(expression, nil)
+}
+
+// CalcWithEnv is Calc with variables resolved from env, which takes
+// precedence over the built-in constants as in EvaluateWithEnv.
+func CalcWithEnv(expression string, env map[string]float64) (float64, error) {
 	trimmed := strings.TrimSpace(expression)
 	if trimmed == "" {
 		return 0, fmt.Errorf("Empty expression")
@@ -349,7 +522,7 @@ func Calc(expression string) (float64, error) {
 		return 0, err
 	}
 
-	result, err := Evaluate(ast)
+	result, err := EvaluateWithEnv(ast, env)
 	if err != nil {
 		return 0, err
 	}
@@ -1,6 +1,7 @@
 package mathexpr
 
 import (
+	"fmt"
 	"math"
 	"strings"
 	"testing"
@@ -183,6 +184,51 @@ func TestTokenizeErrorUnknownChar(t *testing.T) {
 	}
 }
 
+func TestTokenizeIdent(t *testing.T) {
+	tokens, err := Tokenize("x + _rate2*y1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Token{
+		{TokenIdent, "x"},
+		{TokenPlus, "+"},
+		{TokenIdent, "_rate2"},
+		{TokenStar, "*"},
+		{TokenIdent, "y1"},
+	}
+	if len(tokens) != len(expected) {
+		t.Fatalf("expected %d tokens, got %d", len(expected), len(tokens))
+	}
+	for i, tok := range tokens {
+		if tok != expected[i] {
+			t.Errorf("token %d: expected %v, got %v", i, expected[i], tok)
+		}
+	}
+}
+
+func TestTokenizeCall(t *testing.T) {
+	tokens, err := Tokenize("max(1, x)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Token{
+		{TokenIdent, "max"},
+		{TokenLParen, "("},
+		{TokenNumber, "1"},
+		{TokenComma, ","},
+		{TokenIdent, "x"},
+		{TokenRParen, ")"},
+	}
+	if len(tokens) != len(expected) {
+		t.Fatalf("expected %d tokens, got %d", len(expected), len(tokens))
+	}
+	for i, tok := range tokens {
+		if tok != expected[i] {
+			t.Errorf("token %d: expected %v, got %v", i, expected[i], tok)
+		}
+	}
+}
+
 // --- parser tests ---
 
 func TestParseNumber(t *testing.T) {
@@ -345,6 +391,58 @@ func TestParseErrorMissingRParen(t *testing.T) {
 	}
 }
 
+func TestParseVariable(t *testing.T) {
+	node, err := Parse([]Token{{TokenIdent, "x"}, {TokenPlus, "+"}, {TokenNumber, "1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bin, ok := node.(BinaryExpr)
+	if !ok || bin.Op != "+" {
+		t.Fatalf("expected BinaryExpr(+), got %v", node)
+	}
+	if ref, ok := bin.Left.(VariableRef); !ok || ref.Name != "x" {
+		t.Errorf("expected VariableRef(x), got %v", bin.Left)
+	}
+}
+
+func TestParseFunctionCall(t *testing.T) {
+	tokens, err := Tokenize("max(1, 2 + 3)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	node, err := Parse(tokens)
+	if err != nil {
+		t.Fatal(err)
+	}
+	call, ok := node.(FunctionCall)
+	if !ok || call.Name != "max" || len(call.Args) != 2 {
+		t.Fatalf("expected FunctionCall(max, 2 args), got %v", node)
+	}
+	if _, ok := call.Args[1].(BinaryExpr); !ok {
+		t.Errorf("expected second argument to be BinaryExpr, got %v", call.Args[1])
+	}
+}
+
+func TestParseErrorCallMissingRParen(t *testing.T) {
+	tokens, _ := Tokenize("sqrt(2, 3")
+	_, err := Parse(tokens)
+	if err == nil {
+		t.Fatal("expected error for missing rparen")
+	}
+	if want := "Expected rparen but reached end of input"; err.Error() != want {
+		t.Errorf("expected %q, got: %s", want, err.Error())
+	}
+
+	tokens, _ = Tokenize("sqrt(2 3)")
+	_, err = Parse(tokens)
+	if err == nil {
+		t.Fatal("expected error for missing comma")
+	}
+	if want := "Expected rparen but got number:\"3\""; err.Error() != want {
+		t.Errorf("expected %q, got: %s", want, err.Error())
+	}
+}
+
 // --- evaluator tests ---
 
 func TestEvaluateNumber(t *testing.T) {
@@ -459,6 +557,27 @@ func TestEvaluateModuloByZero(t *testing.T) {
 	}
 }
 
+func TestEvaluateWithEnv(t *testing.T) {
+	ast := BinaryExpr{Op: "*", Left: VariableRef{Name: "x"}, Right: NumberLiteral{Value: 3}}
+	result, err := EvaluateWithEnv(ast, map[string]float64{"x": 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result != 12 {
+		t.Errorf("expected 12, got %f", result)
+	}
+}
+
+func TestEvaluateUndefinedVariable(t *testing.T) {
+	_, err := Evaluate(VariableRef{Name: "x"})
+	if err == nil {
+		t.Fatal("expected undefined variable error")
+	}
+	if err.Error() != "undefined variable: x" {
+		t.Errorf("expected 'undefined variable: x', got: %s", err.Error())
+	}
+}
+
 // --- calc (end-to-end) tests ---
 
 func assertCalc(t *testing.T, expr string, expected float64) {
@@ -549,3 +668,127 @@ func TestCalcErrors(t *testing.T) {
 	assertCalcError(t, "2 @ 3", "Unexpected character")
 	assertCalcError(t, "2 +", "end of input")
 }
+
+func TestCalcWithEnv(t *testing.T) {
+	env := map[string]float64{"x": 2, "rate": 0.5, "y_1": -3}
+	tests := []struct {
+		expr     string
+		expected float64
+	}{
+		{"x + 1", 3},
+		{"x ** 3", 8},
+		{"-x * rate", -1},
+		{"(x + y_1) * 10", -10},
+		{"x*x + rate", 4.5},
+	}
+	for _, tt := range tests {
+		result, err := CalcWithEnv(tt.expr, env)
+		if err != nil {
+			t.Errorf("CalcWithEnv(%q): unexpected error: %v", tt.expr, err)
+			continue
+		}
+		if math.Abs(result-tt.expected) > 1e-9 {
+			t.Errorf("CalcWithEnv(%q) = %g, want %g", tt.expr, result, tt.expected)
+		}
+	}
+
+	if _, err := CalcWithEnv("x + z", env); err == nil || err.Error() != "undefined variable: z" {
+		t.Errorf("CalcWithEnv(%q): expected 'undefined variable: z', got %v", "x + z", err)
+	}
+	assertCalcError(t, "x + 1", "undefined variable: x")
+}
+
+func TestCalcConstants(t *testing.T) {
+	assertCalc(t, "pi", math.Pi)
+	assertCalc(t, "2 * pi", 2*math.Pi)
+	assertCalc(t, "e ** 2", math.E*math.E)
+	assertCalc(t, "tau / 2 - pi", 0)
+
+	result, err := CalcWithEnv("pi * 2", map[string]float64{"pi": 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result != 6 {
+		t.Errorf("env pi should override built-in: expected 6, got %g", result)
+	}
+	assertCalcError(t, "PI", "undefined variable: PI")
+}
+
+func TestCalcFunctions(t *testing.T) {
+	assertCalc(t, "sqrt(2)", math.Sqrt2)
+	assertCalc(t, "sin(pi / 2)", 1)
+	assertCalc(t, "cos(0)", 1)
+	assertCalc(t, "tan(pi / 4)", 1)
+	assertCalc(t, "abs(-3) + 1", 4)
+	assertCalc(t, "ln(e ** 2)", 2)
+	assertCalc(t, "log(1000)", 3)
+	assertCalc(t, "exp(1)", math.E)
+	assertCalc(t, "max(1, 5, 3)", 5)
+	assertCalc(t, "min(4, -2)", -2)
+	assertCalc(t, "2 * sqrt(abs(-16)) ** 2", 32)
+	assertCalc(t, "-sqrt(4)", -2)
+}
+
+func TestCalcFunctionErrors(t *testing.T) {
+	assertCalcError(t, "foo(1)", "unknown function: foo")
+	assertCalcError(t, "sqrt(1, 2)", "wrong number of arguments to sqrt")
+	assertCalcError(t, "sqrt()", "wrong number of arguments to sqrt")
+	assertCalcError(t, "max()", "wrong number of arguments to max")
+	assertCalcError(t, "sqrt(1 / 0)", "Division by zero")
+	assertCalcError(t, "1, 2", "Unexpected token after expression")
+}
+
+func TestEvaluateWithFuncs(t *testing.T) {
+	funcs := DefaultFuncs()
+	funcs["double"] = func(args []float64) (float64, error) {
+		if len(args) != 1 {
+			return 0, fmt.Errorf("wrong number of arguments to double")
+		}
+		return 2 * args[0], nil
+	}
+	funcs["hypot"] = func(args []float64) (float64, error) {
+		if len(args) != 2 {
+			return 0, fmt.Errorf("wrong number of arguments to hypot")
+		}
+		return math.Hypot(args[0], args[1]), nil
+	}
+
+	tests := []struct {
+		expr     string
+		expected float64
+	}{
+		{"double(3) + 1", 7},
+		{"hypot(3, 4)", 5},
+		{"double(sqrt(4))", 4},
+	}
+	for _, tt := range tests {
+		tokens, err := Tokenize(tt.expr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ast, err := Parse(tokens)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, err := EvaluateWithFuncs(ast, funcs)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.expr, err)
+			continue
+		}
+		if result != tt.expected {
+			t.Errorf("%s: expected %g, got %g", tt.expr, tt.expected, result)
+		}
+	}
+
+	// Registering into a copy leaves the built-ins untouched.
+	assertCalcError(t, "double(3)", "unknown function: double")
+	if _, ok := DefaultFuncs()["double"]; ok {
+		t.Error("DefaultFuncs should return a fresh copy")
+	}
+
+	// A registry without sqrt does not fall back to the built-in.
+	_, err := EvaluateWithFuncs(FunctionCall{Name: "sqrt", Args: []AstNode{NumberLiteral{Value: 4}}}, FuncRegistry{})
+	if err == nil || err.Error() != "unknown function: sqrt" {
+		t.Errorf("expected 'unknown function: sqrt', got %v", err)
+	}
+}
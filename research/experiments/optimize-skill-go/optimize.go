@@ -1,13 +1,20 @@
 // Package neldermead provides derivative-free optimization using the Nelder-Mead
 // simplex method. Translated from the Type-O optimization reference library.
 //
-// Nodes implemented: vec-ops, result-types, nelder-mead.
+// Nodes implemented: vec-ops, result-types, nelder-mead, line-search, bfgs,
+// finite-diff, gradient-descent, multi-start, brent, coordinate-descent, powell.
 package neldermead
 
 import (
 	"fmt"
 	"math"
+	"math/rand"
+	"runtime"
 	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 )
 
 // ---------------------------------------------------------------------------
@@ -41,6 +48,17 @@ func NormInf(v []float64) float64 {
 	return m
 }
 
+// NormScaled returns the weighted L2 norm sqrt(sum((v[i]/scale[i])^2)).
+// All scale entries must be positive.
+func NormScaled(v, scale []float64) float64 {
+	sum := 0.0
+	for i, x := range v {
+		r := x / scale[i]
+		sum += r * r
+	}
+	return math.Sqrt(sum)
+}
+
 // Scale returns v * s (scalar multiplication).
 func Scale(v []float64, s float64) []float64 {
 	result := make([]float64, len(v))
@@ -83,6 +101,77 @@ func Zeros(n int) []float64 {
 	return make([]float64, n)
 }
 
+// Sum returns the sum of the elements of v (0 for an empty vector).
+func Sum(v []float64) float64 {
+	sum := 0.0
+	for _, x := range v {
+		sum += x
+	}
+	return sum
+}
+
+// Mean returns the arithmetic mean of v. The mean of an empty vector is
+// defined as 0, matching Sum.
+func Mean(v []float64) float64 {
+	if len(v) == 0 {
+		return 0
+	}
+	return Sum(v) / float64(len(v))
+}
+
+// Max returns the largest element of v, or -Inf for an empty vector.
+func Max(v []float64) float64 {
+	if i := ArgMax(v); i >= 0 {
+		return v[i]
+	}
+	return math.Inf(-1)
+}
+
+// Min returns the smallest element of v, or +Inf for an empty vector.
+func Min(v []float64) float64 {
+	if i := ArgMin(v); i >= 0 {
+		return v[i]
+	}
+	return math.Inf(1)
+}
+
+// ArgMax returns the index of the first largest element of v, or -1 if v is empty.
+func ArgMax(v []float64) int {
+	best := -1
+	for i, x := range v {
+		if best < 0 || x > v[best] {
+			best = i
+		}
+	}
+	return best
+}
+
+// ArgMin returns the index of the first smallest element of v, or -1 if v is empty.
+func ArgMin(v []float64) int {
+	best := -1
+	for i, x := range v {
+		if best < 0 || x < v[best] {
+			best = i
+		}
+	}
+	return best
+}
+
+// Clamp returns v with each component clamped into [lo[i], hi[i]].
+// A nil lo or hi leaves that side unbounded.
+func Clamp(v, lo, hi []float64) []float64 {
+	result := Clone(v)
+	for i := range result {
+		if lo != nil && result[i] < lo[i] {
+			result[i] = lo[i]
+		}
+		if hi != nil && result[i] > hi[i] {
+			result[i] = hi[i]
+		}
+	}
+	return result
+}
+
 // AddScaled returns a + s*b (fused scale-and-add, avoids intermediate allocation).
 func AddScaled(a, b []float64, s float64) []float64 {
 	result := make([]float64, len(a))
@@ -124,16 +213,35 @@ type OptimizeResult struct {
 	GradientCalls int       // Number of gradient evaluations
 	Converged     bool      // Whether a convergence criterion was met
 	Message       string    // Human-readable termination reason
+	FunCurve      []float64 // Best value after each iteration (when requested)
+	Restarts      int       // Number of simplex re-initializations performed
+}
+
+// String summarizes the run on one line. Nelder-Mead on the Rosenbrock
+// function from (-1.2, 1) gives
+// "converged in 126 iterations (242 f-evals): f=2.3e-12 at [1, 1] — simplex function spread 8.45e-13 below tolerance".
+func (r OptimizeResult) String() string {
+	status := "stopped"
+	if r.Converged {
+		status = "converged"
+	}
+	coords := make([]string, len(r.X))
+	for i, v := range r.X {
+		coords[i] = strconv.FormatFloat(v, 'g', 4, 64)
+	}
+	reason := strings.TrimPrefix(strings.TrimPrefix(r.Message, "Converged: "), "Stopped: ")
+	return fmt.Sprintf("%s in %d iterations (%d f-evals): f=%.2g at [%s] — %s",
+		status, r.Iterations, r.FunctionCalls, r.Fun, strings.Join(coords, ", "), reason)
 }
 
 // ConvergenceReason describes why the optimizer stopped.
 type ConvergenceReason struct {
-	Kind       string  // "gradient", "step", "function", "maxIterations", "lineSearchFailed"
+	Kind       string  // "gradient", "step", "function", "maxIterations", "lineSearchFailed", "degenerate", "stagnation"
 	GradNorm   float64 // populated for Kind=="gradient"
 	StepNorm   float64 // populated for Kind=="step"
 	FuncChange float64 // populated for Kind=="function"
-	Iterations int     // populated for Kind=="maxIterations"
-	Message    string  // populated for Kind=="lineSearchFailed"
+	Iterations int     // populated for Kind=="maxIterations" or "stagnation"
+	Message    string  // populated for Kind=="lineSearchFailed" or "degenerate"
 }
 
 // CheckConvergence checks criteria in order: gradient -> step -> function -> maxIterations.
@@ -154,7 +262,8 @@ func CheckConvergence(gradNorm, stepNorm, funcChange float64, iteration int, opt
 	return nil
 }
 
-// IsConverged returns true for gradient/step/function; false for maxIterations/lineSearchFailed.
+// IsConverged returns true for gradient/step/function; false for
+// maxIterations/lineSearchFailed/degenerate/stagnation.
 func IsConverged(reason *ConvergenceReason) bool {
 	return reason.Kind == "gradient" || reason.Kind == "step" || reason.Kind == "function"
 }
@@ -172,7 +281,14 @@ func ConvergenceMessage(reason *ConvergenceReason) string {
 		return fmt.Sprintf("Stopped: reached maximum iterations (%d)", reason.Iterations)
 	case "lineSearchFailed":
 		return fmt.Sprintf("Stopped: line search failed (%s)", reason.Message)
+	case "degenerate":
+		return fmt.Sprintf("Stopped: simplex degenerated (%s)", reason.Message)
+	case "stagnation":
+		return fmt.Sprintf("Stopped: no improvement over the last %d iterations", reason.Iterations)
 	default:
+		if reason.Message != "" {
+			return reason.Message
+		}
 		return "Unknown convergence reason"
 	}
 }
@@ -181,25 +297,107 @@ func ConvergenceMessage(reason *ConvergenceReason) string {
 // nelder-mead: Derivative-free simplex optimizer.
 // ---------------------------------------------------------------------------
 
+// IterationState is the snapshot of a Nelder-Mead iteration passed to a
+// Converger, taken after the simplex is sorted.
+type IterationState struct {
+	Iteration int     // Iterations completed so far
+	Best      float64 // Lowest vertex value
+	Worst     float64 // Highest vertex value
+	Diameter  float64 // Largest distance from the best vertex (as used for StepTol)
+	Spread    float64 // Standard deviation of the vertex values (as used for FuncTol)
+}
+
+// Converger is a custom stopping rule. Check returns nil to keep going, or a
+// reason to stop; the run's Converged flag and Message come from IsConverged
+// and ConvergenceMessage, which falls back to reason.Message for custom kinds.
+type Converger interface {
+	Check(state IterationState) *ConvergenceReason
+}
+
 // NelderMeadOptions extends OptimizeOptions with Nelder-Mead-specific parameters.
 type NelderMeadOptions struct {
 	OptimizeOptions
-	Alpha              float64 // Reflection coefficient (default 1.0)
-	Gamma              float64 // Expansion coefficient (default 2.0)
-	Rho                float64 // Contraction coefficient (default 0.5)
-	Sigma              float64 // Shrink coefficient (default 0.5)
+	Alpha               float64 // Reflection coefficient (default 1.0)
+	Gamma               float64 // Expansion coefficient (default 2.0)
+	Rho                 float64 // Contraction coefficient (default 0.5)
+	Sigma               float64 // Shrink coefficient (default 0.5)
 	InitialSimplexScale float64 // Edge length scale (default 0.05)
+
+	// ShrinkAll shrinks the whole simplex toward the best vertex whenever a
+	// contraction fails (default true). When false, a contraction that failed
+	// by no more than ShrinkMargin (relative to the acceptance threshold)
+	// shrinks only the worst vertex, costing 1 evaluation instead of n. That
+	// helps on noisy objectives where narrow failures are common, but the
+	// simplex can lose its shape faster on smooth, badly scaled problems.
+	ShrinkAll    bool
+	ShrinkMargin float64 // Relative margin for ShrinkAll=false (default 0.1)
+
+	// Parallel evaluates the shrunk vertices concurrently on a bounded worker
+	// pool. f must be safe for concurrent calls. Results are identical to the
+	// sequential path since only independent evaluations run in parallel.
+	Parallel bool
+
+	// TargetValue, when set, stops the run as soon as the best vertex value
+	// drops to or below it.
+	TargetValue *float64
+
+	// Scale, when set, measures the simplex diameter with NormScaled (a
+	// weighted L2 norm, in place of the default infinity norm) so each
+	// coordinate is compared in its own units. It must have one
+	// positive, finite entry per coordinate; otherwise the run is rejected
+	// as invalid input.
+	Scale []float64
+
+	// StagnationWindow, when positive, stops the run if the best value has not
+	// improved by more than StagnationTol over that many iterations.
+	StagnationWindow int
+	StagnationTol    float64
+
+	// Deadline, when non-zero, is checked at the start of each iteration; once
+	// passed, the best point so far is returned.
+	Deadline time.Time
+
+	// InfeasibleOnError makes NelderMeadE treat points where f returns an
+	// error as infeasible (+Inf) instead of aborting the run.
+	InfeasibleOnError bool
+
+	// RNG supplies randomness for random restarts. When nil, a source with a
+	// fixed package seed is used so runs are reproducible by default.
+	RNG *rand.Rand
+
+	// RecordFunCurve fills OptimizeResult.FunCurve with the best value seen
+	// at the end of each iteration.
+	RecordFunCurve bool
+
+	// RestartEvery, when positive, rebuilds the simplex around the best
+	// vertex every RestartEvery iterations, keeping its current diameter
+	// (measured with Scale when set). The rebuild shares its iteration with
+	// the step that follows, so it does not use up MaxIterations.
+	RestartEvery int
+
+	// Converger, when set, is consulted after the built-in checks each
+	// iteration.
+	Converger Converger
+
+	// stop, when set, is polled at the start of each iteration and ends the
+	// run with the given message if it returns a non-empty string.
+	stop func() string
+
+	// ignoreSpread skips the FuncTol spread check; see Minimize1D.
+	ignoreSpread bool
 }
 
 // DefaultNelderMeadOptions returns NelderMeadOptions with standard defaults.
 func DefaultNelderMeadOptions() NelderMeadOptions {
 	return NelderMeadOptions{
-		OptimizeOptions:    DefaultOptions(),
-		Alpha:              1.0,
-		Gamma:              2.0,
-		Rho:                0.5,
-		Sigma:              0.5,
+		OptimizeOptions:     DefaultOptions(),
+		Alpha:               1.0,
+		Gamma:               2.0,
+		Rho:                 0.5,
+		Sigma:               0.5,
 		InitialSimplexScale: 0.05,
+		ShrinkAll:           true,
+		ShrinkMargin:        0.1,
 	}
 }
 
@@ -220,7 +418,170 @@ func createInitialSimplex(x0 []float64, scale float64) [][]float64 {
 	return simplex
 }
 
+// createScaledSimplex builds an axis-aligned simplex around x0 whose edge
+// along coordinate i is size*scale[i], so its NormScaled diameter is size.
+func createScaledSimplex(x0 []float64, size float64, scale []float64) [][]float64 {
+	n := len(x0)
+	simplex := make([][]float64, n+1)
+	simplex[0] = Clone(x0)
+
+	for i := 0; i < n; i++ {
+		vertex := Clone(x0)
+		vertex[i] += size * scale[i]
+		simplex[i+1] = vertex
+	}
+
+	return simplex
+}
+
+// evaluateVertices stores f(vertices[i]) in fValues[i]. When parallel is set,
+// evaluations are spread over at most GOMAXPROCS workers.
+func evaluateVertices(f func([]float64) float64, vertices [][]float64, fValues []float64, parallel bool) {
+	if !parallel {
+		for i, v := range vertices {
+			fValues[i] = f(v)
+		}
+		return
+	}
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(vertices) {
+		workers = len(vertices)
+	}
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				fValues[i] = f(vertices[i])
+			}
+		}()
+	}
+	for i := range vertices {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// Centroid returns the arithmetic mean of the given points.
+// All points must have the same dimension; vertices must be non-empty.
+func Centroid(vertices [][]float64) []float64 {
+	centroid := Clone(vertices[0])
+	for _, v := range vertices[1:] {
+		for j := range centroid {
+			centroid[j] += v[j]
+		}
+	}
+	for j := range centroid {
+		centroid[j] /= float64(len(vertices))
+	}
+	return centroid
+}
+
+// determinant returns det(m) by Gaussian elimination with partial pivoting.
+// m is a square matrix stored as rows and is not modified.
+func determinant(m [][]float64) float64 {
+	n := len(m)
+	a := make([][]float64, n)
+	for i, row := range m {
+		a[i] = Clone(row)
+	}
+
+	det := 1.0
+	for col := 0; col < n; col++ {
+		pivot := col
+		for r := col + 1; r < n; r++ {
+			if math.Abs(a[r][col]) > math.Abs(a[pivot][col]) {
+				pivot = r
+			}
+		}
+		if a[pivot][col] == 0 {
+			return 0
+		}
+		if pivot != col {
+			a[pivot], a[col] = a[col], a[pivot]
+			det = -det
+		}
+		det *= a[col][col]
+		for r := col + 1; r < n; r++ {
+			factor := a[r][col] / a[col][col]
+			for c := col; c < n; c++ {
+				a[r][c] -= factor * a[col][c]
+			}
+		}
+	}
+	return det
+}
+
+// SimplexVolume returns the volume |det(V)| / n! of an n-dimensional simplex
+// with n+1 vertices, where the rows of V are the edges from vertex 0. An
+// empty simplex has volume 0.
+func SimplexVolume(simplex [][]float64) float64 {
+	if len(simplex) == 0 {
+		return 0
+	}
+	n := len(simplex) - 1
+	edges := make([][]float64, n)
+	factorial := 1.0
+	for i := 1; i <= n; i++ {
+		edges[i-1] = Sub(simplex[i], simplex[0])
+		factorial *= float64(i)
+	}
+	return math.Abs(determinant(edges)) / factorial
+}
+
+// sortSimplex returns the vertices and values ordered by ascending function
+// value. Ties keep their original relative order so runs are reproducible.
+func sortSimplex(simplex [][]float64, fValues []float64) ([][]float64, []float64) {
+	indices := make([]int, len(simplex))
+	for i := range indices {
+		indices[i] = i
+	}
+	sort.SliceStable(indices, func(a, b int) bool {
+		return fValues[indices[a]] < fValues[indices[b]]
+	})
+	newSimplex := make([][]float64, len(simplex))
+	newFValues := make([]float64, len(simplex))
+	for i, idx := range indices {
+		newSimplex[i] = simplex[idx]
+		newFValues[i] = fValues[idx]
+	}
+	return newSimplex, newFValues
+}
+
+// validateStart returns a description of why x0 cannot start a run, or "".
+func validateStart(x0 []float64) string {
+	if len(x0) == 0 {
+		return "x0 must have at least one element"
+	}
+	for i, v := range x0 {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Sprintf("x0[%d] is not finite (%v)", i, v)
+		}
+	}
+	return ""
+}
+
+// validateScale returns a description of why scale cannot weight n
+// coordinates, or "".
+func validateScale(scale []float64, n int) string {
+	if len(scale) != n {
+		return fmt.Sprintf("Scale has %d entries, want %d", len(scale), n)
+	}
+	for i, v := range scale {
+		if !(v > 0) || math.IsInf(v, 0) {
+			return fmt.Sprintf("Scale[%d] is not positive and finite (%v)", i, v)
+		}
+	}
+	return ""
+}
+
 // NelderMead minimizes f starting from x0 using the Nelder-Mead simplex method.
+// An empty or non-finite x0, or a Scale that does not fit it, is rejected
+// without evaluating f.
 // Pass nil for opts to use defaults.
 func NelderMead(f func([]float64) float64, x0 []float64, opts *NelderMeadOptions) OptimizeResult {
 	var o NelderMeadOptions
@@ -230,6 +591,19 @@ func NelderMead(f func([]float64) float64, x0 []float64, opts *NelderMeadOptions
 		o = DefaultNelderMeadOptions()
 	}
 
+	problem := validateStart(x0)
+	if problem == "" && o.Scale != nil {
+		problem = validateScale(o.Scale, len(x0))
+	}
+	if problem != "" {
+		return OptimizeResult{
+			X:         Clone(x0),
+			Fun:       math.NaN(),
+			Converged: false,
+			Message:   "Invalid input: " + problem,
+		}
+	}
+
 	n := len(x0)
 
 	// Initialize simplex
@@ -240,36 +614,72 @@ func NelderMead(f func([]float64) float64, x0 []float64, opts *NelderMeadOptions
 	}
 	functionCalls := n + 1
 
+	// Best point ever evaluated; the simplex best can lag behind it, e.g.
+	// when the loop ends right after a vertex was replaced.
+	bestEverX := simplex[0]
+	bestEverF := fValues[0]
+	track := func(x []float64, fx float64) {
+		if fx < bestEverF {
+			bestEverX, bestEverF = x, fx
+		}
+	}
+	for i := 1; i <= n; i++ {
+		track(simplex[i], fValues[i])
+	}
+
 	iteration := 0
+	var bestHistory []float64 // fValues[0] at the start of each iteration
+	restarts := 0
+
+	var funCurve []float64
+	recordCurve := func() {
+		if o.RecordFunCurve && len(funCurve) < iteration {
+			funCurve = append(funCurve, bestEverF)
+		}
+	}
+
+	// finish builds the result from the best point ever evaluated.
+	finish := func(converged bool, message string) OptimizeResult {
+		recordCurve()
+		return OptimizeResult{
+			X:             Clone(bestEverX),
+			Fun:           bestEverF,
+			Gradient:      nil,
+			Iterations:    iteration,
+			FunctionCalls: functionCalls,
+			GradientCalls: 0,
+			Converged:     converged,
+			Message:       message,
+			FunCurve:      funCurve,
+			Restarts:      restarts,
+		}
+	}
 
 	for iteration < o.MaxIterations {
-		// Sort vertices by function value (ascending)
-		indices := make([]int, n+1)
-		for i := range indices {
-			indices[i] = i
-		}
-		sort.Slice(indices, func(a, b int) bool {
-			return fValues[indices[a]] < fValues[indices[b]]
-		})
-		newSimplex := make([][]float64, n+1)
-		newFValues := make([]float64, n+1)
-		for i, idx := range indices {
-			newSimplex[i] = simplex[idx]
-			newFValues[i] = fValues[idx]
-		}
-		simplex = newSimplex
-		fValues = newFValues
+		recordCurve()
+
+		if !o.Deadline.IsZero() && !time.Now().Before(o.Deadline) {
+			return finish(false, "Stopped: reached time deadline")
+		}
+		if o.stop != nil {
+			if message := o.stop(); message != "" {
+				return finish(false, message)
+			}
+		}
+
+		simplex, fValues = sortSimplex(simplex, fValues)
 
 		fBest := fValues[0]
 		fWorst := fValues[n]
 		fSecondWorst := fValues[n-1]
 
-		// Check convergence: function value spread (std dev)
-		fMean := 0.0
-		for _, fv := range fValues {
-			fMean += fv
+		// Check target: any value at or below TargetValue is good enough
+		if o.TargetValue != nil && fBest <= *o.TargetValue {
+			return finish(true, fmt.Sprintf("Converged: reached target value %.2e", *o.TargetValue))
 		}
-		fMean /= float64(n + 1)
+
+		// Check convergence: function value spread (std dev)
+		fMean := Mean(fValues)
 
 		fStd := 0.0
 		for _, fv := range fValues {
@@ -277,58 +687,74 @@ func NelderMead(f func([]float64) float64, x0 []float64, opts *NelderMeadOptions
 		}
 		fStd = math.Sqrt(fStd / float64(n+1))
 
-		if fStd < o.FuncTol {
-			return OptimizeResult{
-				X:             Clone(simplex[0]),
-				Fun:           fBest,
-				Gradient:      nil,
-				Iterations:    iteration,
-				FunctionCalls: functionCalls,
-				GradientCalls: 0,
-				Converged:     true,
-				Message:       fmt.Sprintf("Converged: simplex function spread %.2e below tolerance", fStd),
-			}
+		if fStd < o.FuncTol && !o.ignoreSpread {
+			return finish(true, fmt.Sprintf("Converged: simplex function spread %.2e below tolerance", fStd))
 		}
 
 		// Check convergence: simplex diameter
 		diameter := 0.0
 		for i := 1; i <= n; i++ {
-			d := NormInf(Sub(simplex[i], simplex[0]))
+			var d float64
+			if o.Scale != nil {
+				d = NormScaled(Sub(simplex[i], simplex[0]), o.Scale)
+			} else {
+				d = NormInf(Sub(simplex[i], simplex[0]))
+			}
 			if d > diameter {
 				diameter = d
 			}
 		}
 
 		if diameter < o.StepTol {
-			return OptimizeResult{
-				X:             Clone(simplex[0]),
-				Fun:           fBest,
-				Gradient:      nil,
-				Iterations:    iteration,
-				FunctionCalls: functionCalls,
-				GradientCalls: 0,
-				Converged:     true,
-				Message:       fmt.Sprintf("Converged: simplex diameter %.2e below tolerance", diameter),
+			return finish(true, fmt.Sprintf("Converged: simplex diameter %.2e below tolerance", diameter))
+		}
+
+		if o.Converger != nil {
+			state := IterationState{Iteration: iteration, Best: fBest, Worst: fWorst, Diameter: diameter, Spread: fStd}
+			if reason := o.Converger.Check(state); reason != nil {
+				return finish(IsConverged(reason), ConvergenceMessage(reason))
+			}
+		}
+
+		// Check stagnation: best value barely moved over the window
+		if o.StagnationWindow > 0 {
+			bestHistory = append(bestHistory, fBest)
+			if k := len(bestHistory) - 1 - o.StagnationWindow; k >= 0 && bestHistory[k]-fBest <= o.StagnationTol {
+				reason := &ConvergenceReason{Kind: "stagnation", Iterations: o.StagnationWindow}
+				return finish(false, ConvergenceMessage(reason))
 			}
 		}
 
 		iteration++
 
-		// Compute centroid of all vertices except the worst
-		centroid := Clone(simplex[0])
-		for i := 1; i < n; i++ {
-			for j := 0; j < n; j++ {
-				centroid[j] += simplex[i][j]
+		// Periodic restart: rebuild an axis-aligned simplex of the current
+		// size around the best vertex to undo accumulated distortion, then
+		// take this iteration's step from it.
+		if o.RestartEvery > 0 && iteration%o.RestartEvery == 0 {
+			if o.Scale != nil {
+				simplex = createScaledSimplex(simplex[0], diameter, o.Scale)
+			} else {
+				simplex = createInitialSimplex(simplex[0], diameter/math.Max(NormInf(simplex[0]), 1.0))
 			}
-		}
-		for j := 0; j < n; j++ {
-			centroid[j] /= float64(n)
+			evaluateVertices(f, simplex[1:], fValues[1:], o.Parallel)
+			functionCalls += n
+			for i := 1; i <= n; i++ {
+				track(simplex[i], fValues[i])
+			}
+			restarts++
+
+			simplex, fValues = sortSimplex(simplex, fValues)
+			fBest, fWorst, fSecondWorst = fValues[0], fValues[n], fValues[n-1]
 		}
 
+		// Compute centroid of all vertices except the worst
+		centroid := Centroid(simplex[:n])
+
 		// Reflection: x_r = centroid + alpha * (centroid - worst)
 		reflected := AddScaled(centroid, Sub(centroid, simplex[n]), o.Alpha)
 		fReflected := f(reflected)
 		functionCalls++
+		track(reflected, fReflected)
 
 		if fReflected < fSecondWorst && fReflected >= fBest {
 			// Accept reflection
@@ -342,6 +768,7 @@ func NelderMead(f func([]float64) float64, x0 []float64, opts *NelderMeadOptions
 			expanded := AddScaled(centroid, Sub(reflected, centroid), o.Gamma)
 			fExpanded := f(expanded)
 			functionCalls++
+			track(expanded, fExpanded)
 
 			if fExpanded < fReflected {
 				simplex[n] = expanded
@@ -354,11 +781,15 @@ func NelderMead(f func([]float64) float64, x0 []float64, opts *NelderMeadOptions
 		}
 
 		// Contraction
+		var contracted []float64
+		var fContracted, threshold float64
 		if fReflected < fWorst {
 			// Outside contraction
-			contracted := AddScaled(centroid, Sub(reflected, centroid), o.Rho)
-			fContracted := f(contracted)
+			contracted = AddScaled(centroid, Sub(reflected, centroid), o.Rho)
+			fContracted = f(contracted)
 			functionCalls++
+			track(contracted, fContracted)
+			threshold = fReflected
 
 			if fContracted <= fReflected {
 				simplex[n] = contracted
@@ -367,9 +798,11 @@ func NelderMead(f func([]float64) float64, x0 []float64, opts *NelderMeadOptions
 			}
 		} else {
 			// Inside contraction
-			contracted := AddScaled(centroid, Sub(simplex[n], centroid), o.Rho)
-			fContracted := f(contracted)
+			contracted = AddScaled(centroid, Sub(simplex[n], centroid), o.Rho)
+			fContracted = f(contracted)
 			functionCalls++
+			track(contracted, fContracted)
+			threshold = fWorst
 
 			if fContracted < fWorst {
 				simplex[n] = contracted
@@ -378,20 +811,780 @@ func NelderMead(f func([]float64) float64, x0 []float64, opts *NelderMeadOptions
 			}
 		}
 
+		// Contraction failed narrowly: pull in only the worst vertex instead
+		// of paying n evaluations for a full shrink.
+		if !o.ShrinkAll && fContracted <= threshold+o.ShrinkMargin*math.Abs(threshold) {
+			simplex[n] = Add(simplex[0], Scale(Sub(simplex[n], simplex[0]), o.Sigma))
+			fValues[n] = f(simplex[n])
+			functionCalls++
+			track(simplex[n], fValues[n])
+			continue
+		}
+
 		// Shrink: move all vertices towards the best
+		moved := false
 		for i := 1; i <= n; i++ {
-			simplex[i] = Add(simplex[0], Scale(Sub(simplex[i], simplex[0]), o.Sigma))
-			fValues[i] = f(simplex[i])
-			functionCalls++
+			shrunk := Add(simplex[0], Scale(Sub(simplex[i], simplex[0]), o.Sigma))
+			if NormInf(Sub(shrunk, simplex[i])) > 0 {
+				moved = true
+			}
+			simplex[i] = shrunk
+		}
+		if !moved {
+			// The simplex has collapsed to floating-point resolution without
+			// meeting the spread or diameter tolerances.
+			reason := &ConvergenceReason{Kind: "degenerate", Message: "shrink step left every vertex unchanged"}
+			return finish(false, ConvergenceMessage(reason))
+		}
+		evaluateVertices(f, simplex[1:], fValues[1:], o.Parallel)
+		functionCalls += n
+		for i := 1; i <= n; i++ {
+			track(simplex[i], fValues[i])
 		}
 	}
 
 	// Max iterations reached
+	return finish(false, fmt.Sprintf("Stopped: reached maximum iterations (%d)", o.MaxIterations))
+}
+
+// NelderMeadContinue warm-restarts NelderMead from a previous result, building
+// a fresh simplex around prev.X. Iterations and FunctionCalls in the returned
+// result include the totals carried by prev. Pass nil for opts to use defaults.
+func NelderMeadContinue(f func([]float64) float64, prev OptimizeResult, opts *NelderMeadOptions) OptimizeResult {
+	result := NelderMead(f, prev.X, opts)
+	result.Iterations += prev.Iterations
+	result.FunctionCalls += prev.FunctionCalls
+	return result
+}
+
+// NelderMeadE is NelderMead for objectives that can fail. By default the first
+// error from f aborts the run: no further points are evaluated and the error
+// is returned alongside the best result found before it. FunctionCalls counts
+// only the calls to f that ran. With
+// InfeasibleOnError set, failing points score +Inf so the simplex steers away
+// from them, and the returned error is always nil.
+func NelderMeadE(f func([]float64) (float64, error), x0 []float64, opts *NelderMeadOptions) (OptimizeResult, error) {
+	var o NelderMeadOptions
+	if opts != nil {
+		o = *opts
+	} else {
+		o = DefaultNelderMeadOptions()
+	}
+
+	var mu sync.Mutex // f may be called from Parallel workers
+	var firstErr error
+	calls := 0 // evaluations of f itself, not the skipped ones after an abort
+	wrapped := func(x []float64) float64 {
+		mu.Lock()
+		aborted := firstErr != nil
+		if !aborted {
+			calls++
+		}
+		mu.Unlock()
+		if aborted {
+			return math.Inf(1)
+		}
+		fx, err := f(x)
+		if err == nil {
+			return fx
+		}
+		if !o.InfeasibleOnError {
+			mu.Lock()
+			if firstErr == nil {
+				firstErr = fmt.Errorf("objective failed at %v: %w", x, err)
+			}
+			mu.Unlock()
+		}
+		return math.Inf(1)
+	}
+	o.stop = func() string {
+		mu.Lock()
+		defer mu.Unlock()
+		if firstErr != nil {
+			return "Stopped: objective returned an error"
+		}
+		return ""
+	}
+
+	result := NelderMead(wrapped, x0, &o)
+	result.FunctionCalls = calls
+	return result, firstErr
+}
+
+// Minimize1D minimizes a scalar function by running NelderMead on the
+// two-vertex simplex around x0. Pass nil for opts to use defaults.
+//
+// FuncTol is not used: the two vertices often straddle the minimum with
+// equal values, so a zero spread is not evidence of convergence. Runs end on
+// StepTol or MaxIterations instead.
+func Minimize1D(f func(float64) float64, x0 float64, opts *NelderMeadOptions) (x float64, fx float64, converged bool) {
+	var o NelderMeadOptions
+	if opts != nil {
+		o = *opts
+	} else {
+		o = DefaultNelderMeadOptions()
+	}
+	o.ignoreSpread = true
+
+	result := NelderMead(func(v []float64) float64 { return f(v[0]) }, []float64{x0}, &o)
+	return result.X[0], result.Fun, result.Converged
+}
+
+// Cached wraps f with a memo keyed on the point's coordinates rounded to 12
+// significant digits, so points that differ by less than that share a value.
+// calls counts evaluations of the underlying f. The wrapper is not safe for
+// concurrent use, so do not combine it with Parallel.
+func Cached(f func([]float64) float64) (wrapped func([]float64) float64, calls *int) {
+	memo := make(map[string]float64)
+	count := 0
+	wrapped = func(x []float64) float64 {
+		var key strings.Builder
+		for _, v := range x {
+			key.WriteString(strconv.FormatFloat(v, 'g', 12, 64))
+			key.WriteByte(',')
+		}
+		if fx, ok := memo[key.String()]; ok {
+			return fx
+		}
+		count++
+		fx := f(x)
+		memo[key.String()] = fx
+		return fx
+	}
+	return wrapped, &count
+}
+
+// ---------------------------------------------------------------------------
+// line-search: Step size selection along a descent direction.
+// ---------------------------------------------------------------------------
+
+// LineSearchResult holds the outcome of a line search.
+type LineSearchResult struct {
+	Alpha         float64 // Step size found
+	FNew          float64 // Function value at x + alpha*d
+	FunctionCalls int     // Number of function evaluations used
+	Success       bool    // Whether the sufficient decrease condition was met
+}
+
+// BacktrackingLineSearch finds alpha satisfying the Armijo condition
+// f(x + alpha*d) <= f(x) + c1*alpha*g'*d, starting at alpha=1 and halving
+// (c1=1e-4, at most 20 trials).
+func BacktrackingLineSearch(f func([]float64) float64, x, d []float64, fx float64, gx []float64) LineSearchResult {
+	const (
+		c1      = 1e-4
+		rho     = 0.5
+		maxIter = 20
+	)
+
+	dg := Dot(gx, d) // directional derivative (should be negative)
+	alpha := 1.0
+	functionCalls := 0
+
+	for i := 0; i < maxIter; i++ {
+		fNew := f(AddScaled(x, d, alpha))
+		functionCalls++
+
+		if fNew <= fx+c1*alpha*dg {
+			return LineSearchResult{Alpha: alpha, FNew: fNew, FunctionCalls: functionCalls, Success: true}
+		}
+		alpha *= rho
+	}
+
+	return LineSearchResult{
+		Alpha:         alpha,
+		FNew:          f(AddScaled(x, d, alpha)),
+		FunctionCalls: functionCalls + 1,
+		Success:       false,
+	}
+}
+
+// ---------------------------------------------------------------------------
+// bfgs: Quasi-Newton optimizer with an inverse Hessian approximation.
+// ---------------------------------------------------------------------------
+
+// identityMatrix returns the n×n identity as a slice of rows.
+func identityMatrix(n int) [][]float64 {
+	m := make([][]float64, n)
+	for i := range m {
+		m[i] = make([]float64, n)
+		m[i][i] = 1
+	}
+	return m
+}
+
+// matVecMul returns M*v where M is a slice of rows.
+func matVecMul(m [][]float64, v []float64) []float64 {
+	result := make([]float64, len(m))
+	for i, row := range m {
+		result[i] = Dot(row, v)
+	}
+	return result
+}
+
+// bfgsUpdate applies the inverse Hessian update
+// H' = (I - rho*s*y')*H*(I - rho*y*s') + rho*s*s' (Nocedal & Wright, Eq. 6.17).
+func bfgsUpdate(h [][]float64, s, y []float64, rho float64) [][]float64 {
+	n := len(s)
+	hy := matVecMul(h, y)
+	yhy := Dot(y, hy)
+
+	hNew := make([][]float64, n)
+	for i := 0; i < n; i++ {
+		hNew[i] = make([]float64, n)
+		for j := 0; j < n; j++ {
+			hNew[i][j] = h[i][j] -
+				rho*(s[i]*hy[j]+hy[i]*s[j]) +
+				rho*(1+rho*yhy)*s[i]*s[j]
+		}
+	}
+	return hNew
+}
+
+// BFGS minimizes f using the BFGS quasi-Newton method with a backtracking
+// line search. grad must return the analytic gradient of f.
+// Pass nil for opts to use defaults.
+func BFGS(f func([]float64) float64, grad func([]float64) []float64, x0 []float64, opts *OptimizeOptions) OptimizeResult {
+	var o OptimizeOptions
+	if opts != nil {
+		o = *opts
+	} else {
+		o = DefaultOptions()
+	}
+
+	x := Clone(x0)
+	fx := f(x)
+	gx := grad(x)
+	functionCalls := 1
+	gradientCalls := 1
+
+	h := identityMatrix(len(x0))
+
+	// Check if already at a minimum
+	if reason := CheckConvergence(NormInf(gx), math.Inf(1), math.Inf(1), 0, o); reason != nil && IsConverged(reason) {
+		return OptimizeResult{
+			X:             x,
+			Fun:           fx,
+			Gradient:      Clone(gx),
+			Iterations:    0,
+			FunctionCalls: functionCalls,
+			GradientCalls: gradientCalls,
+			Converged:     true,
+			Message:       ConvergenceMessage(reason),
+		}
+	}
+
+	for iteration := 1; iteration <= o.MaxIterations; iteration++ {
+		// Search direction: d = -H*g
+		d := Negate(matVecMul(h, gx))
+
+		ls := BacktrackingLineSearch(f, x, d, fx, gx)
+		functionCalls += ls.FunctionCalls
+
+		if !ls.Success {
+			reason := &ConvergenceReason{Kind: "lineSearchFailed", Message: "no step satisfied sufficient decrease"}
+			return OptimizeResult{
+				X:             x,
+				Fun:           fx,
+				Gradient:      Clone(gx),
+				Iterations:    iteration,
+				FunctionCalls: functionCalls,
+				GradientCalls: gradientCalls,
+				Converged:     false,
+				Message:       ConvergenceMessage(reason),
+			}
+		}
+
+		xNew := AddScaled(x, d, ls.Alpha)
+		gNew := grad(xNew)
+		gradientCalls++
+
+		s := Sub(xNew, x)
+		y := Sub(gNew, gx)
+		funcChange := math.Abs(ls.FNew - fx)
+
+		x = xNew
+		fx = ls.FNew
+		gx = gNew
+
+		if reason := CheckConvergence(NormInf(gx), NormInf(s), funcChange, iteration, o); reason != nil {
+			return OptimizeResult{
+				X:             Clone(x),
+				Fun:           fx,
+				Gradient:      Clone(gx),
+				Iterations:    iteration,
+				FunctionCalls: functionCalls,
+				GradientCalls: gradientCalls,
+				Converged:     IsConverged(reason),
+				Message:       ConvergenceMessage(reason),
+			}
+		}
+
+		// Curvature guard: skip the update if y's is not safely positive,
+		// which backtracking (unlike Wolfe) does not guarantee.
+		ys := Dot(y, s)
+		if ys <= 1e-10 {
+			continue
+		}
+		h = bfgsUpdate(h, s, y, 1/ys)
+	}
+
+	return OptimizeResult{
+		X:             Clone(x),
+		Fun:           fx,
+		Gradient:      Clone(gx),
+		Iterations:    o.MaxIterations,
+		FunctionCalls: functionCalls,
+		GradientCalls: gradientCalls,
+		Converged:     false,
+		Message:       fmt.Sprintf("Stopped: reached maximum iterations (%d)", o.MaxIterations),
+	}
+}
+
+// ---------------------------------------------------------------------------
+// finite-diff: Gradient estimation from function values.
+// ---------------------------------------------------------------------------
+
+// cbrtEps is the central-difference step scale, balancing truncation and
+// rounding error for O(h^2) accuracy.
+var cbrtEps = math.Cbrt(2.220446049250313e-16)
+
+// CentralDiffGradient estimates the gradient of f at x by central differences:
+// g_i = (f(x + h*e_i) - f(x - h*e_i)) / 2h with h = cbrt(eps) * max(|x_i|, 1).
+// Costs 2n function evaluations.
+func CentralDiffGradient(f func([]float64) float64, x []float64) []float64 {
+	grad := make([]float64, len(x))
+	for i := range x {
+		h := cbrtEps * math.Max(math.Abs(x[i]), 1.0)
+		xPlus := Clone(x)
+		xMinus := Clone(x)
+		xPlus[i] += h
+		xMinus[i] -= h
+		grad[i] = (f(xPlus) - f(xMinus)) / (2 * h)
+	}
+	return grad
+}
+
+// GradCheck compares grad(x) against CentralDiffGradient(f, x). maxErr is the
+// largest componentwise error |g_i - n_i| / max(|n_i|, 1), so it is absolute
+// for small components and relative for large ones; ok reports maxErr <= tol.
+func GradCheck(f func([]float64) float64, grad func([]float64) []float64, x []float64, tol float64) (maxErr float64, ok bool) {
+	analytic := grad(x)
+	numeric := CentralDiffGradient(f, x)
+	for i := range numeric {
+		e := math.Abs(analytic[i]-numeric[i]) / math.Max(math.Abs(numeric[i]), 1.0)
+		if e > maxErr {
+			maxErr = e
+		}
+	}
+	return maxErr, maxErr <= tol
+}
+
+// ---------------------------------------------------------------------------
+// gradient-descent: Steepest descent with numeric gradients.
+// ---------------------------------------------------------------------------
+
+// GradientDescent minimizes f by steepest descent, estimating the gradient
+// with CentralDiffGradient and choosing each step by backtracking.
+// FunctionCalls includes the 2n evaluations spent on every gradient estimate.
+// Pass nil for opts to use defaults.
+func GradientDescent(f func([]float64) float64, x0 []float64, opts *OptimizeOptions) OptimizeResult {
+	var o OptimizeOptions
+	if opts != nil {
+		o = *opts
+	} else {
+		o = DefaultOptions()
+	}
+
+	n := len(x0)
+	x := Clone(x0)
+	fx := f(x)
+	gx := CentralDiffGradient(f, x)
+	functionCalls := 1 + 2*n
+	gradientCalls := 1
+
+	// Check if already at a minimum
+	if reason := CheckConvergence(NormInf(gx), math.Inf(1), math.Inf(1), 0, o); reason != nil && IsConverged(reason) {
+		return OptimizeResult{
+			X:             x,
+			Fun:           fx,
+			Gradient:      Clone(gx),
+			Iterations:    0,
+			FunctionCalls: functionCalls,
+			GradientCalls: gradientCalls,
+			Converged:     true,
+			Message:       ConvergenceMessage(reason),
+		}
+	}
+
+	for iteration := 1; iteration <= o.MaxIterations; iteration++ {
+		d := Negate(gx)
+
+		ls := BacktrackingLineSearch(f, x, d, fx, gx)
+		functionCalls += ls.FunctionCalls
+
+		if !ls.Success {
+			reason := &ConvergenceReason{Kind: "lineSearchFailed", Message: "no step satisfied sufficient decrease"}
+			return OptimizeResult{
+				X:             x,
+				Fun:           fx,
+				Gradient:      Clone(gx),
+				Iterations:    iteration,
+				FunctionCalls: functionCalls,
+				GradientCalls: gradientCalls,
+				Converged:     false,
+				Message:       ConvergenceMessage(reason),
+			}
+		}
+
+		xNew := AddScaled(x, d, ls.Alpha)
+		gNew := CentralDiffGradient(f, xNew)
+		functionCalls += 2 * n
+		gradientCalls++
+
+		stepNorm := NormInf(Sub(xNew, x))
+		funcChange := math.Abs(ls.FNew - fx)
+
+		x = xNew
+		fx = ls.FNew
+		gx = gNew
+
+		if reason := CheckConvergence(NormInf(gx), stepNorm, funcChange, iteration, o); reason != nil {
+			return OptimizeResult{
+				X:             Clone(x),
+				Fun:           fx,
+				Gradient:      Clone(gx),
+				Iterations:    iteration,
+				FunctionCalls: functionCalls,
+				GradientCalls: gradientCalls,
+				Converged:     IsConverged(reason),
+				Message:       ConvergenceMessage(reason),
+			}
+		}
+	}
+
+	return OptimizeResult{
+		X:             Clone(x),
+		Fun:           fx,
+		Gradient:      Clone(gx),
+		Iterations:    o.MaxIterations,
+		FunctionCalls: functionCalls,
+		GradientCalls: gradientCalls,
+		Converged:     false,
+		Message:       fmt.Sprintf("Stopped: reached maximum iterations (%d)", o.MaxIterations),
+	}
+}
+
+// ---------------------------------------------------------------------------
+// multi-start: Best-of-N Nelder-Mead runs.
+// ---------------------------------------------------------------------------
+
+// MultiStart runs NelderMead from each start point and returns the result with
+// the lowest Fun. FunctionCalls is the total across all runs; the remaining
+// fields describe the winning run. Pass nil for opts to use defaults.
+func MultiStart(f func([]float64) float64, starts [][]float64, opts *NelderMeadOptions) OptimizeResult {
+	var best OptimizeResult
+	totalCalls := 0
+
+	for i, x0 := range starts {
+		result := NelderMead(f, x0, opts)
+		totalCalls += result.FunctionCalls
+		// A NaN best (an invalid start) loses to any later result.
+		if i == 0 || math.IsNaN(best.Fun) || result.Fun < best.Fun {
+			best = result
+		}
+	}
+
+	best.FunctionCalls = totalCalls
+	return best
+}
+
+// defaultSeed seeds the RNG used when NelderMeadOptions.RNG is nil.
+const defaultSeed = 1
+
+// rngOrDefault returns o.RNG, or a fresh generator with the package seed.
+func rngOrDefault(o *NelderMeadOptions) *rand.Rand {
+	if o != nil && o.RNG != nil {
+		return o.RNG
+	}
+	return rand.New(rand.NewSource(defaultSeed))
+}
+
+// MultiStartRandom runs MultiStart from count points drawn uniformly from the
+// box [lo, hi] using the options' RNG. Pass nil for opts to use defaults.
+func MultiStartRandom(f func([]float64) float64, lo, hi []float64, count int, opts *NelderMeadOptions) OptimizeResult {
+	rng := rngOrDefault(opts)
+	starts := make([][]float64, count)
+	for k := range starts {
+		x0 := make([]float64, len(lo))
+		for i := range x0 {
+			x0[i] = lo[i] + rng.Float64()*(hi[i]-lo[i])
+		}
+		starts[k] = x0
+	}
+	return MultiStart(f, starts, opts)
+}
+
+// ---------------------------------------------------------------------------
+// brent: Derivative-free 1-D minimization along a direction.
+// ---------------------------------------------------------------------------
+
+// invPhi is 1/φ, the golden-section interval reduction factor.
+const invPhi = 0.6180339887498949
+
+// lineMinimize approximately minimizes g(t) = f(x + t*d) by bracketing from
+// t=0 (where f(x) = fx) and refining the bracket with Brent's method:
+// parabolic interpolation through the three best points, falling back to a
+// golden-section step when the parabola is not trusted (Numerical Recipes,
+// §10.3). It never returns a point worse than t=0. calls counts evaluations
+// of f.
+func lineMinimize(f func([]float64) float64, x, d []float64, fx float64) (t, ft float64, calls int) {
+	const (
+		maxBracket = 50
+		maxRefine  = 100
+		relTol     = 1.5e-8 // ~sqrt(eps): a minimum cannot be resolved finer
+		absTol     = 1e-10  // keeps a minimum at t=0 from demanding relTol*0
+		cGold      = 1 - invPhi
+	)
+
+	bestT, bestF := 0.0, fx
+	g := func(t float64) float64 {
+		calls++
+		v := f(AddScaled(x, d, t))
+		if v < bestF {
+			bestT, bestF = t, v
+		}
+		return v
+	}
+
+	// Bracket: walk downhill with golden-ratio growth until f rises.
+	a, fa := 0.0, fx
+	b, fb := 1.0, g(1.0)
+	if fb > fa {
+		a, b, fa, fb = b, a, fb, fa
+	}
+	c := b + (b-a)/invPhi
+	fc := g(c)
+	for i := 0; fc < fb && i < maxBracket; i++ {
+		a, b, fb = b, c, fc
+		c = b + (b-a)/invPhi
+		fc = g(c)
+	}
+	if fc < fb {
+		return bestT, bestF, calls // unbounded along d; keep the best seen
+	}
+
+	// Refine [lo, hi] around b with Brent's method. u is the best point so
+	// far, w the second best, and v the previous w; e is the step taken two
+	// iterations ago, which a parabolic step must undercut to be accepted.
+	lo, hi := math.Min(a, c), math.Max(a, c)
+	u, w, v := b, b, b
+	fu, fw, fv := fb, fb, fb
+	var step, e float64
+	for i := 0; i < maxRefine; i++ {
+		mid := (lo + hi) / 2
+		tol1 := relTol*math.Abs(u) + absTol
+		tol2 := 2 * tol1
+		if math.Abs(u-mid) <= tol2-(hi-lo)/2 {
+			break
+		}
+
+		golden := true
+		if math.Abs(e) > tol1 {
+			r := (u - w) * (fu - fv)
+			q := (u - v) * (fu - fw)
+			p := (u-v)*q - (u-w)*r
+			q = 2 * (q - r)
+			if q > 0 {
+				p = -p
+			}
+			q = math.Abs(q)
+			if math.Abs(p) < math.Abs(q*e/2) && p > q*(lo-u) && p < q*(hi-u) {
+				e, step = step, p/q
+				golden = false
+				if trial := u + step; trial-lo < tol2 || hi-trial < tol2 {
+					step = math.Copysign(tol1, mid-u)
+				}
+			}
+		}
+		if golden {
+			if u >= mid {
+				e = lo - u
+			} else {
+				e = hi - u
+			}
+			step = cGold * e
+		}
+
+		trial := u + step
+		if math.Abs(step) < tol1 {
+			trial = u + math.Copysign(tol1, step)
+		}
+		fTrial := g(trial)
+
+		if fTrial <= fu {
+			if trial >= u {
+				lo = u
+			} else {
+				hi = u
+			}
+			v, w, u = w, u, trial
+			fv, fw, fu = fw, fu, fTrial
+			continue
+		}
+		if trial < u {
+			lo = trial
+		} else {
+			hi = trial
+		}
+		if fTrial <= fw || w == u {
+			v, w = w, trial
+			fv, fw = fw, fTrial
+		} else if fTrial <= fv || v == u || v == w {
+			v, fv = trial, fTrial
+		}
+	}
+	return bestT, bestF, calls
+}
+
+// ---------------------------------------------------------------------------
+// coordinate-descent: Axis-by-axis derivative-free minimization.
+// ---------------------------------------------------------------------------
+
+// CoordinateDescent minimizes f by cycling through the coordinate axes and
+// minimizing along each with a Brent line search. One iteration is a
+// full sweep over all axes; convergence uses CheckConvergence on the sweep's
+// step and function change. Pass nil for opts to use defaults.
+func CoordinateDescent(f func([]float64) float64, x0 []float64, opts *OptimizeOptions) OptimizeResult {
+	var o OptimizeOptions
+	if opts != nil {
+		o = *opts
+	} else {
+		o = DefaultOptions()
+	}
+
+	n := len(x0)
+	x := Clone(x0)
+	fx := f(x)
+	functionCalls := 1
+
+	for iteration := 1; iteration <= o.MaxIterations; iteration++ {
+		xStart, fStart := x, fx
+
+		for i := 0; i < n; i++ {
+			axis := Zeros(n)
+			axis[i] = 1
+			t, ft, calls := lineMinimize(f, x, axis, fx)
+			functionCalls += calls
+			x = AddScaled(x, axis, t)
+			fx = ft
+		}
+
+		stepNorm := NormInf(Sub(x, xStart))
+		funcChange := math.Abs(fStart - fx)
+		if reason := CheckConvergence(math.Inf(1), stepNorm, funcChange, iteration, o); reason != nil {
+			return OptimizeResult{
+				X:             Clone(x),
+				Fun:           fx,
+				Gradient:      nil,
+				Iterations:    iteration,
+				FunctionCalls: functionCalls,
+				GradientCalls: 0,
+				Converged:     IsConverged(reason),
+				Message:       ConvergenceMessage(reason),
+			}
+		}
+	}
+
+	return OptimizeResult{
+		X:             Clone(x),
+		Fun:           fx,
+		Gradient:      nil,
+		Iterations:    o.MaxIterations,
+		FunctionCalls: functionCalls,
+		GradientCalls: 0,
+		Converged:     false,
+		Message:       fmt.Sprintf("Stopped: reached maximum iterations (%d)", o.MaxIterations),
+	}
+}
+
+// ---------------------------------------------------------------------------
+// powell: Derivative-free conjugate direction-set method.
+// ---------------------------------------------------------------------------
+
+// Powell minimizes f with Powell's conjugate-direction method: each iteration
+// line-minimizes along every direction in the set, then replaces the direction
+// of largest decrease with the net displacement when that is expected to help
+// (Numerical Recipes, §10.7). Line searches use Brent's method.
+// Pass nil for opts to use defaults.
+func Powell(f func([]float64) float64, x0 []float64, opts *OptimizeOptions) OptimizeResult {
+	var o OptimizeOptions
+	if opts != nil {
+		o = *opts
+	} else {
+		o = DefaultOptions()
+	}
+
+	n := len(x0)
+	x := Clone(x0)
+	fx := f(x)
+	functionCalls := 1
+
+	directions := identityMatrix(n)
+
+	for iteration := 1; iteration <= o.MaxIterations; iteration++ {
+		xStart, fStart := x, fx
+
+		// Line-minimize along each direction, remembering the biggest drop.
+		biggest, biggestDrop := 0, 0.0
+		for i, d := range directions {
+			fBefore := fx
+			t, ft, calls := lineMinimize(f, x, d, fx)
+			functionCalls += calls
+			x = AddScaled(x, d, t)
+			fx = ft
+			if fBefore-fx > biggestDrop {
+				biggest, biggestDrop = i, fBefore-fx
+			}
+		}
+
+		stepNorm := NormInf(Sub(x, xStart))
+		funcChange := math.Abs(fStart - fx)
+		if reason := CheckConvergence(math.Inf(1), stepNorm, funcChange, iteration, o); reason != nil {
+			return OptimizeResult{
+				X:             Clone(x),
+				Fun:           fx,
+				Gradient:      nil,
+				Iterations:    iteration,
+				FunctionCalls: functionCalls,
+				GradientCalls: 0,
+				Converged:     IsConverged(reason),
+				Message:       ConvergenceMessage(reason),
+			}
+		}
+
+		// Try the extrapolated point along the net displacement.
+		displacement := Sub(x, xStart)
+		fExtrapolated := f(AddScaled(x, displacement, 1))
+		functionCalls++
+		if fExtrapolated < fStart {
+			a := fStart - fx - biggestDrop
+			b := fStart - fExtrapolated
+			if 2*(fStart-2*fx+fExtrapolated)*a*a < biggestDrop*b*b {
+				t, ft, calls := lineMinimize(f, x, displacement, fx)
+				functionCalls += calls
+				x = AddScaled(x, displacement, t)
+				fx = ft
+				directions[biggest] = directions[n-1]
+				directions[n-1] = displacement
+			}
+		}
+	}
+
 	return OptimizeResult{
-		X:             Clone(simplex[0]),
-		Fun:           fValues[0],
+		X:             Clone(x),
+		Fun:           fx,
 		Gradient:      nil,
-		Iterations:    iteration,
+		Iterations:    o.MaxIterations,
 		FunctionCalls: functionCalls,
 		GradientCalls: 0,
 		Converged:     false,
@@ -1,8 +1,13 @@
 package neldermead
 
 import (
+	"errors"
+	"fmt"
 	"math"
+	"math/rand"
+	"strings"
 	"testing"
+	"time"
 )
 
 // ---------------------------------------------------------------------------
@@ -39,6 +44,10 @@ func sphere(x []float64) float64 {
 	return s
 }
 
+func sphereGrad(x []float64) []float64 {
+	return Scale(x, 2)
+}
+
 func booth(x []float64) float64 {
 	// f(x,y) = (x + 2y - 7)^2 + (2x + y - 5)^2
 	a := x[0] + 2*x[1] - 7
@@ -61,6 +70,11 @@ func rosenbrock(x []float64) float64 {
 	return a*a + 100*b*b
 }
 
+func rosenbrockGrad(x []float64) []float64 {
+	b := x[1] - x[0]*x[0]
+	return []float64{-2*(1-x[0]) - 400*x[0]*b, 200 * b}
+}
+
 func himmelblau(x []float64) float64 {
 	// f(x,y) = (x^2 + y - 11)^2 + (x + y^2 - 7)^2
 	a := x[0]*x[0] + x[1] - 11
@@ -99,6 +113,15 @@ func TestNormInf(t *testing.T) {
 	}
 }
 
+func TestNormScaled(t *testing.T) {
+	if got := NormScaled([]float64{3000, 4}, []float64{1000, 1}); !approxEqual(got, 5, tol) {
+		t.Errorf("NormScaled([3000,4],[1000,1]) = %v, want 5", got)
+	}
+	if got := NormScaled([]float64{3, 4}, []float64{1, 1}); got != Norm([]float64{3, 4}) {
+		t.Errorf("unit scale should match Norm, got %v", got)
+	}
+}
+
 func TestScale(t *testing.T) {
 	sliceEqual(t, Scale([]float64{1, 2}, 3), []float64{3, 6}, tol)
 	sliceEqual(t, Scale([]float64{1, 2}, 0), []float64{0, 0}, tol)
@@ -135,6 +158,65 @@ func TestAddScaled(t *testing.T) {
 	sliceEqual(t, AddScaled([]float64{1, 2}, []float64{3, 4}, 2), []float64{7, 10}, tol)
 }
 
+func TestSum(t *testing.T) {
+	if got := Sum([]float64{1, 2, 3.5}); got != 6.5 {
+		t.Errorf("Sum([1,2,3.5]) = %v, want 6.5", got)
+	}
+	if got := Sum(nil); got != 0 {
+		t.Errorf("Sum(nil) = %v, want 0", got)
+	}
+}
+
+func TestMean(t *testing.T) {
+	if got := Mean([]float64{1, 2, 6}); got != 3 {
+		t.Errorf("Mean([1,2,6]) = %v, want 3", got)
+	}
+	if got := Mean([]float64{-4}); got != -4 {
+		t.Errorf("Mean([-4]) = %v, want -4", got)
+	}
+	if got := Mean([]float64{}); got != 0 {
+		t.Errorf("Mean([]) = %v, want 0", got)
+	}
+}
+
+func TestMaxMin(t *testing.T) {
+	v := []float64{-3, 7, -9, 7, 2}
+	if got := Max(v); got != 7 {
+		t.Errorf("Max = %v, want 7", got)
+	}
+	if got := Min(v); got != -9 {
+		t.Errorf("Min = %v, want -9", got)
+	}
+	if got := Max([]float64{-5, -2}); got != -2 {
+		t.Errorf("Max of negatives = %v, want -2", got)
+	}
+	if !math.IsInf(Max(nil), -1) || !math.IsInf(Min(nil), 1) {
+		t.Errorf("Max/Min of empty = %v/%v, want -Inf/+Inf", Max(nil), Min(nil))
+	}
+}
+
+func TestArgMaxArgMin(t *testing.T) {
+	v := []float64{-3, 7, -9, 7, -9}
+	if got := ArgMax(v); got != 1 {
+		t.Errorf("ArgMax = %d, want 1 (first of tie)", got)
+	}
+	if got := ArgMin(v); got != 2 {
+		t.Errorf("ArgMin = %d, want 2 (first of tie)", got)
+	}
+	if ArgMax(nil) != -1 || ArgMin(nil) != -1 {
+		t.Error("ArgMax/ArgMin of empty should be -1")
+	}
+}
+
+func TestClamp(t *testing.T) {
+	lo := []float64{0, 0, 0}
+	hi := []float64{1, 1, 1}
+	sliceEqual(t, Clamp([]float64{-1, 0.5, 2}, lo, hi), []float64{0, 0.5, 1}, tol)
+	sliceEqual(t, Clamp([]float64{-1, 0.5, 2}, nil, hi), []float64{-1, 0.5, 1}, tol)
+	sliceEqual(t, Clamp([]float64{-1, 0.5, 2}, lo, nil), []float64{0, 0.5, 2}, tol)
+	sliceEqual(t, Clamp([]float64{-1, 2}, nil, nil), []float64{-1, 2}, tol)
+}
+
 // Purity checks
 func TestAddPurity(t *testing.T) {
 	a := []float64{1, 2}
@@ -148,6 +230,14 @@ func TestAddPurity(t *testing.T) {
 	}
 }
 
+func TestClampPurity(t *testing.T) {
+	v := []float64{-1, 2}
+	Clamp(v, []float64{0, 0}, []float64{1, 1})
+	if v[0] != -1 || v[1] != 2 {
+		t.Error("Clamp must not modify v")
+	}
+}
+
 func TestScalePurity(t *testing.T) {
 	v := []float64{1, 2}
 	Scale(v, 3)
@@ -246,6 +336,8 @@ func TestIsConverged(t *testing.T) {
 		{"function", true},
 		{"maxIterations", false},
 		{"lineSearchFailed", false},
+		{"degenerate", false},
+		{"stagnation", false},
 	}
 	for _, tc := range tests {
 		r := &ConvergenceReason{Kind: tc.kind}
@@ -265,6 +357,8 @@ func TestConvergenceMessage(t *testing.T) {
 		{&ConvergenceReason{Kind: "function", FuncChange: 1e-13}, "function change"},
 		{&ConvergenceReason{Kind: "maxIterations", Iterations: 1000}, "maximum iterations"},
 		{&ConvergenceReason{Kind: "lineSearchFailed", Message: "no step"}, "line search failed"},
+		{&ConvergenceReason{Kind: "degenerate", Message: "collapsed"}, "simplex degenerated"},
+		{&ConvergenceReason{Kind: "stagnation", Iterations: 10}, "no improvement"},
 	}
 	for _, tc := range tests {
 		msg := ConvergenceMessage(tc.reason)
@@ -278,6 +372,37 @@ func TestConvergenceMessage(t *testing.T) {
 	}
 }
 
+func TestOptimizeResultString(t *testing.T) {
+	r := OptimizeResult{
+		X:             []float64{1, 0.5},
+		Fun:           1.2e-9,
+		Iterations:    57,
+		FunctionCalls: 312,
+		Converged:     true,
+		Message:       ConvergenceMessage(&ConvergenceReason{Kind: "gradient", GradNorm: 3e-9}),
+	}
+	got := r.String()
+	want := "converged in 57 iterations (312 f-evals): f=1.2e-09 at [1, 0.5] — gradient norm 3.00e-09 below tolerance"
+	if got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	opts := DefaultNelderMeadOptions()
+	opts.MaxIterations = 3
+	stopped := NelderMead(rosenbrock, []float64{-1.2, 1.0}, &opts)
+	for _, sub := range []string{"stopped in 3 iterations", "f-evals", "reached maximum iterations (3)"} {
+		if !containsSubstr(stopped.String(), sub) {
+			t.Errorf("String() = %q, want it to contain %q", stopped.String(), sub)
+		}
+	}
+}
+
+func ExampleOptimizeResult_String() {
+	result := NelderMead(rosenbrock, []float64{-1.2, 1.0}, nil)
+	fmt.Println(result)
+	// Output: converged in 126 iterations (242 f-evals): f=2.3e-12 at [1, 1] — simplex function spread 8.45e-13 below tolerance
+}
+
 func containsSubstr(s, sub string) bool {
 	return len(s) >= len(sub) && (s == sub || len(s) > 0 && containsHelper(s, sub))
 }
@@ -411,6 +536,577 @@ func TestNelderMead_FunctionCallsCounted(t *testing.T) {
 	}
 }
 
+func TestNelderMead_ParallelMatchesSequential(t *testing.T) {
+	slow := func(x []float64) float64 {
+		time.Sleep(10 * time.Microsecond)
+		return rosenbrock(x)
+	}
+	opts := DefaultNelderMeadOptions()
+	opts.MaxIterations = 200
+	seq := NelderMead(slow, []float64{-1.2, 1.0}, &opts)
+	opts.Parallel = true
+	par := NelderMead(slow, []float64{-1.2, 1.0}, &opts)
+
+	if par.Fun != seq.Fun || par.Iterations != seq.Iterations || par.FunctionCalls != seq.FunctionCalls {
+		t.Errorf("parallel (fun=%v, iter=%d, calls=%d) differs from sequential (fun=%v, iter=%d, calls=%d)",
+			par.Fun, par.Iterations, par.FunctionCalls, seq.Fun, seq.Iterations, seq.FunctionCalls)
+	}
+	sliceEqual(t, par.X, seq.X, tol)
+}
+
+func TestNelderMead_TargetValue(t *testing.T) {
+	full := NelderMead(sphere, []float64{5, 5}, nil)
+
+	opts := DefaultNelderMeadOptions()
+	target := 1e-2
+	opts.TargetValue = &target
+	result := NelderMead(sphere, []float64{5, 5}, &opts)
+	if !result.Converged {
+		t.Fatalf("expected convergence, got: %s", result.Message)
+	}
+	if result.Fun > target {
+		t.Errorf("fun = %v, want <= %v", result.Fun, target)
+	}
+	if !containsSubstr(result.Message, "reached target value") {
+		t.Errorf("message = %q, want it to mention the target", result.Message)
+	}
+	if result.Iterations >= full.Iterations {
+		t.Errorf("iterations = %d, want fewer than full run's %d", result.Iterations, full.Iterations)
+	}
+}
+
+func TestNelderMead_Degenerate(t *testing.T) {
+	// With both tolerances disabled the simplex shrinks until it collapses.
+	opts := DefaultNelderMeadOptions()
+	opts.FuncTol = -1
+	opts.StepTol = 0
+	result := NelderMead(booth, []float64{0, 0}, &opts)
+	if result.Converged {
+		t.Error("a collapsed simplex should not report convergence")
+	}
+	if !containsSubstr(result.Message, "degenerated") {
+		t.Errorf("message = %q, want degenerate reason", result.Message)
+	}
+	if result.Iterations >= opts.MaxIterations {
+		t.Errorf("iterations = %d, want fewer than %d", result.Iterations, opts.MaxIterations)
+	}
+}
+
+func TestNelderMeadContinue_TwoStages(t *testing.T) {
+	opts := DefaultNelderMeadOptions()
+	opts.MaxIterations = 20
+	first := NelderMead(sphere, []float64{5, 5}, &opts)
+	if first.Converged {
+		t.Fatal("first stage should stop at maxIterations")
+	}
+
+	var firstPoint []float64
+	recording := func(x []float64) float64 {
+		if firstPoint == nil {
+			firstPoint = Clone(x)
+		}
+		return sphere(x)
+	}
+	second := NelderMeadContinue(recording, first, nil)
+
+	sliceEqual(t, firstPoint, first.X, tol)
+	if !second.Converged {
+		t.Fatalf("expected convergence, got: %s", second.Message)
+	}
+	if second.Fun > first.Fun {
+		t.Errorf("fun = %v, want <= first stage %v", second.Fun, first.Fun)
+	}
+	if second.Iterations <= first.Iterations || second.FunctionCalls <= first.FunctionCalls {
+		t.Errorf("totals not accumulated: iterations %d (first %d), calls %d (first %d)",
+			second.Iterations, first.Iterations, second.FunctionCalls, first.FunctionCalls)
+	}
+}
+
+func TestNelderMead_ReturnsBestEverEvaluated(t *testing.T) {
+	for maxIter := 1; maxIter <= 10; maxIter++ {
+		minSeen := math.Inf(1)
+		recording := func(x []float64) float64 {
+			fx := sphere(x)
+			minSeen = math.Min(minSeen, fx)
+			return fx
+		}
+		opts := DefaultNelderMeadOptions()
+		opts.MaxIterations = maxIter
+		result := NelderMead(recording, []float64{5, 5}, &opts)
+		if result.Fun != minSeen {
+			t.Errorf("maxIterations=%d: fun = %v, want minimum ever observed %v", maxIter, result.Fun, minSeen)
+		}
+		if sphere(result.X) != result.Fun {
+			t.Errorf("maxIterations=%d: f(X) = %v does not match Fun %v", maxIter, sphere(result.X), result.Fun)
+		}
+	}
+}
+
+// restrictedSphere is sphere on x[0] >= 1 and an error elsewhere, so the
+// unconstrained minimum lies in the failing region.
+func restrictedSphere(x []float64) (float64, error) {
+	if x[0] < 1 {
+		return 0, errors.New("outside domain")
+	}
+	return sphere(x), nil
+}
+
+func TestNelderMeadE_AbortsOnError(t *testing.T) {
+	calls := 0
+	counted := func(x []float64) (float64, error) {
+		calls++
+		return restrictedSphere(x)
+	}
+	result, err := NelderMeadE(counted, []float64{5, 5}, nil)
+	if err == nil {
+		t.Fatal("expected an error from the failing region")
+	}
+	if !containsSubstr(err.Error(), "outside domain") {
+		t.Errorf("err = %v, want it to wrap the objective error", err)
+	}
+	if result.Converged {
+		t.Error("aborted run should not report convergence")
+	}
+	if math.IsInf(result.Fun, 0) {
+		t.Errorf("fun = %v, want the best finite value found before the error", result.Fun)
+	}
+	if result.FunctionCalls != calls {
+		t.Errorf("function calls = %d, want the %d calls f received", result.FunctionCalls, calls)
+	}
+}
+
+func TestNelderMeadE_InfeasibleOnError(t *testing.T) {
+	opts := DefaultNelderMeadOptions()
+	opts.InfeasibleOnError = true
+	result, err := NelderMeadE(restrictedSphere, []float64{5, 5}, &opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Converged {
+		t.Fatalf("expected convergence, got: %s", result.Message)
+	}
+	if result.X[0] < 1 || !approxEqual(result.X[0], 1, 1e-2) || !approxEqual(result.X[1], 0, 1e-2) {
+		t.Errorf("x = %v, want near the feasible minimum [1,0]", result.X)
+	}
+}
+
+func TestMinimize1D(t *testing.T) {
+	quadratic := func(x float64) float64 { return (x - 3) * (x - 3) }
+	for _, x0 := range []float64{0, 3, -10, 100} {
+		x, fx, converged := Minimize1D(quadratic, x0, nil)
+		if !converged {
+			t.Errorf("x0=%v: expected convergence", x0)
+		}
+		if !approxEqual(x, 3, 1e-3) || fx >= 1e-6 {
+			t.Errorf("x0=%v: got x=%v, f=%v; want x near 3, f near 0", x0, x, fx)
+		}
+	}
+}
+
+func TestNelderMead_1DFuncTol(t *testing.T) {
+	// From x0=3 the initial vertices are 3 and 3.15, which straddle the
+	// minimum with equal values, so the spread starts at zero.
+	straddled := func(x float64) float64 { return (x - 3.075) * (x - 3.075) }
+
+	// Plain NelderMead keeps FuncTol in 1-D and stops on the spread.
+	result := NelderMead(func(v []float64) float64 { return straddled(v[0]) }, []float64{3}, nil)
+	if !result.Converged || !strings.Contains(result.Message, "spread") {
+		t.Errorf("got converged=%v, message %q; want spread convergence", result.Converged, result.Message)
+	}
+
+	// Minimize1D ignores FuncTol and keeps going to the minimum.
+	x, _, converged := Minimize1D(straddled, 3, nil)
+	if !converged || !approxEqual(x, 3.075, 1e-3) {
+		t.Errorf("Minimize1D got x=%v, converged=%v; want x near 3.075", x, converged)
+	}
+}
+
+func TestNelderMead_ScaledStepTolerance(t *testing.T) {
+	// x[0] lives in the thousands, x[1] near 1.
+	badlyScaled := func(x []float64) float64 {
+		a := (x[0] - 1000) / 1000
+		b := x[1] - 1
+		return a*a + b*b
+	}
+	opts := DefaultNelderMeadOptions()
+	opts.FuncTol = -1 // only the diameter criterion applies
+	opts.StepTol = 1e-4
+	plain := NelderMead(badlyScaled, []float64{500, 0}, &opts)
+
+	opts.Scale = []float64{1000, 1}
+	scaled := NelderMead(badlyScaled, []float64{500, 0}, &opts)
+
+	if !plain.Converged || !scaled.Converged {
+		t.Fatalf("expected both to converge: %q / %q", plain.Message, scaled.Message)
+	}
+	if !approxEqual(scaled.X[0]/1000, 1, 1e-3) || !approxEqual(scaled.X[1], 1, 1e-3) {
+		t.Errorf("scaled x = %v, want near [1000,1]", scaled.X)
+	}
+	if scaled.Iterations >= plain.Iterations {
+		t.Errorf("scaled iterations = %d, want fewer than unscaled %d", scaled.Iterations, plain.Iterations)
+	}
+}
+
+func TestNelderMead_InvalidScale(t *testing.T) {
+	calls := 0
+	f := func(x []float64) float64 {
+		calls++
+		return sphere(x)
+	}
+	for _, scale := range [][]float64{{1}, {1, 1, 1}, {1, 0}, {1, -2}, {math.NaN(), 1}, {math.Inf(1), 1}} {
+		opts := DefaultNelderMeadOptions()
+		opts.Scale = scale
+		result := NelderMead(f, []float64{1, 1}, &opts)
+		if result.Converged || !math.IsNaN(result.Fun) || !strings.HasPrefix(result.Message, "Invalid input: Scale") {
+			t.Errorf("Scale %v: got converged=%v, fun=%v, message %q; want invalid input", scale, result.Converged, result.Fun, result.Message)
+		}
+	}
+	if calls != 0 {
+		t.Errorf("f called %d times, want 0", calls)
+	}
+}
+
+func TestNelderMead_Stagnation(t *testing.T) {
+	// Zero everywhere inside the unit box, so the best value stops improving
+	// once a vertex lands there.
+	flatBottom := func(x []float64) float64 {
+		s := 0.0
+		for _, v := range x {
+			d := math.Max(math.Abs(v)-1, 0)
+			s += d * d
+		}
+		return s
+	}
+	opts := DefaultNelderMeadOptions()
+	opts.FuncTol = -1
+	opts.StepTol = 0
+	opts.StagnationWindow = 10
+	opts.StagnationTol = 1e-12
+	result := NelderMead(flatBottom, []float64{5, 5}, &opts)
+	if result.Converged {
+		t.Error("stagnation should not report convergence")
+	}
+	if !containsSubstr(result.Message, "no improvement") {
+		t.Errorf("message = %q, want stagnation reason", result.Message)
+	}
+	if result.Iterations >= opts.MaxIterations {
+		t.Errorf("iterations = %d, want fewer than %d", result.Iterations, opts.MaxIterations)
+	}
+	if result.Fun != 0 {
+		t.Errorf("fun = %v, want 0", result.Fun)
+	}
+}
+
+func TestNelderMead_Deadline(t *testing.T) {
+	slow := func(x []float64) float64 {
+		time.Sleep(time.Millisecond)
+		return rosenbrock(x)
+	}
+	opts := DefaultNelderMeadOptions()
+	opts.MaxIterations = 100000
+	opts.FuncTol = -1
+	opts.StepTol = 0
+	opts.Deadline = time.Now().Add(20 * time.Millisecond)
+
+	start := time.Now()
+	result := NelderMead(slow, []float64{-1.2, 1.0}, &opts)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("took %v, want prompt return after deadline", elapsed)
+	}
+	if result.Converged {
+		t.Error("deadline stop should not report convergence")
+	}
+	if !containsSubstr(result.Message, "time deadline") {
+		t.Errorf("message = %q, want deadline reason", result.Message)
+	}
+	if result.Fun > rosenbrock([]float64{-1.2, 1.0}) {
+		t.Errorf("fun = %v, want best-so-far no worse than start", result.Fun)
+	}
+}
+
+func TestCached(t *testing.T) {
+	cached, calls := Cached(sphere)
+
+	upper := 2.0
+	x := []float64{upper + 0.5, 1}
+	if got := cached(x); got != sphere(x) {
+		t.Errorf("cached(x) = %v, want %v", got, sphere(x))
+	}
+
+	// Clamping both points to the same bound re-evaluates the same point.
+	clamped := []float64{math.Min(x[0], upper), 1}
+	cached(clamped)
+	clampedAgain := []float64{math.Min(3.7, upper), 1}
+	if got := cached(clampedAgain); got != sphere(clamped) {
+		t.Errorf("cached(clamped) = %v, want %v", got, sphere(clamped))
+	}
+	cached([]float64{2 + 1e-15, 1}) // within rounding tolerance
+	if *calls != 2 {
+		t.Errorf("underlying calls = %d, want 2", *calls)
+	}
+}
+
+func TestNelderMead_CachedCountsRealCalls(t *testing.T) {
+	cached, calls := Cached(sphere)
+	result := NelderMead(cached, []float64{5, 5}, nil)
+	if *calls > result.FunctionCalls {
+		t.Errorf("underlying calls = %d, want <= reported %d", *calls, result.FunctionCalls)
+	}
+}
+
+func TestNelderMead_RejectsNaNStart(t *testing.T) {
+	calls := 0
+	counted := func(x []float64) float64 {
+		calls++
+		return sphere(x)
+	}
+	result := NelderMead(counted, []float64{1, math.NaN()}, nil)
+	if result.Converged {
+		t.Error("NaN start should not converge")
+	}
+	if !containsSubstr(result.Message, "x0[1] is not finite") {
+		t.Errorf("message = %q, want it to name the bad component", result.Message)
+	}
+	if calls != 0 || result.FunctionCalls != 0 {
+		t.Errorf("f called %d times (reported %d), want 0", calls, result.FunctionCalls)
+	}
+}
+
+func TestNelderMead_RejectsEmptyStart(t *testing.T) {
+	result := NelderMead(sphere, []float64{}, nil)
+	if result.Converged {
+		t.Error("empty start should not converge")
+	}
+	if !containsSubstr(result.Message, "at least one element") {
+		t.Errorf("message = %q, want empty-input reason", result.Message)
+	}
+}
+
+func TestNelderMead_FunCurve(t *testing.T) {
+	opts := DefaultNelderMeadOptions()
+	opts.RecordFunCurve = true
+	result := NelderMead(sphere, []float64{5, 5}, &opts)
+	if len(result.FunCurve) != result.Iterations {
+		t.Fatalf("len(FunCurve) = %d, want one entry per iteration (%d)", len(result.FunCurve), result.Iterations)
+	}
+	for i := 1; i < len(result.FunCurve); i++ {
+		if result.FunCurve[i] > result.FunCurve[i-1] {
+			t.Fatalf("curve increased at %d: %v > %v", i, result.FunCurve[i], result.FunCurve[i-1])
+		}
+	}
+	if last := result.FunCurve[len(result.FunCurve)-1]; last != result.Fun {
+		t.Errorf("last curve value = %v, want Fun %v", last, result.Fun)
+	}
+
+	plain := NelderMead(sphere, []float64{5, 5}, nil)
+	if plain.FunCurve != nil {
+		t.Error("FunCurve should be nil unless requested")
+	}
+}
+
+func TestCentroid(t *testing.T) {
+	points := [][]float64{{0, 0}, {3, 0}, {0, 6}}
+	sliceEqual(t, Centroid(points), []float64{1, 2}, tol)
+	if points[0][0] != 0 || points[0][1] != 0 {
+		t.Error("Centroid must not modify its input")
+	}
+}
+
+func TestSimplexVolume(t *testing.T) {
+	unit := [][]float64{{0, 0}, {1, 0}, {0, 1}}
+	if got := SimplexVolume(unit); !approxEqual(got, 0.5, tol) {
+		t.Errorf("unit right simplex area = %v, want 0.5", got)
+	}
+	// Translation and vertex order do not change the volume.
+	moved := [][]float64{{3, 4}, {3, 5}, {4, 4}}
+	if got := SimplexVolume(moved); !approxEqual(got, 0.5, tol) {
+		t.Errorf("translated simplex area = %v, want 0.5", got)
+	}
+	tetra := [][]float64{{0, 0, 0}, {2, 0, 0}, {0, 2, 0}, {0, 0, 2}}
+	if got := SimplexVolume(tetra); !approxEqual(got, 8.0/6, tol) {
+		t.Errorf("tetrahedron volume = %v, want 4/3", got)
+	}
+	collapsed := [][]float64{{0, 0}, {1, 1}, {2, 2}}
+	if got := SimplexVolume(collapsed); got != 0 {
+		t.Errorf("collinear simplex area = %v, want 0", got)
+	}
+	if got := SimplexVolume(nil); got != 0 {
+		t.Errorf("empty simplex volume = %v, want 0", got)
+	}
+}
+
+func TestSortSimplex_StableTies(t *testing.T) {
+	// Enough vertices to leave the insertion-sort fast path of sort.Slice.
+	const m = 40
+	simplex := make([][]float64, m)
+	fValues := make([]float64, m)
+	for i := range simplex {
+		simplex[i] = []float64{float64(i)}
+		fValues[i] = float64(i % 3) // many equal values
+	}
+	sorted, sortedF := sortSimplex(simplex, fValues)
+	for i := 1; i < m; i++ {
+		if sortedF[i] < sortedF[i-1] {
+			t.Fatalf("values not ascending at %d: %v", i, sortedF)
+		}
+		if sortedF[i] == sortedF[i-1] && sorted[i][0] < sorted[i-1][0] {
+			t.Errorf("tie at %d not in original order: %v before %v", i, sorted[i-1][0], sorted[i][0])
+		}
+	}
+}
+
+func TestNelderMead_ConstantObjectiveKeepsStart(t *testing.T) {
+	constant := func(x []float64) float64 { return 1 }
+	result := NelderMead(constant, []float64{2, 3, 4}, nil)
+	sliceEqual(t, result.X, []float64{2, 3, 4}, tol)
+}
+
+func TestNelderMead_ShrinkAllFalseSavesCalls(t *testing.T) {
+	// A rippled bowl makes contractions fail often, and usually narrowly.
+	rippled := func(x []float64) float64 {
+		s := sphere(x)
+		for _, v := range x {
+			s += 0.01 * math.Abs(math.Sin(100*v))
+		}
+		return s
+	}
+	x0 := []float64{3, 2.5, -1, 2, 4}
+	opts := DefaultNelderMeadOptions()
+	full := NelderMead(rippled, x0, &opts)
+	opts.ShrinkAll = false
+	partial := NelderMead(rippled, x0, &opts)
+
+	if !full.Converged || !partial.Converged {
+		t.Fatalf("expected both to converge: %q / %q", full.Message, partial.Message)
+	}
+	if partial.FunctionCalls >= full.FunctionCalls {
+		t.Errorf("ShrinkAll=false used %d calls, want fewer than %d", partial.FunctionCalls, full.FunctionCalls)
+	}
+	if partial.Fun > full.Fun+1e-3 {
+		t.Errorf("ShrinkAll=false fun = %v, want comparable to %v", partial.Fun, full.Fun)
+	}
+}
+
+func TestNelderMead_RestartEvery(t *testing.T) {
+	opts := DefaultNelderMeadOptions()
+	opts.MaxIterations = 5000
+	opts.FuncTol = 1e-15
+	opts.StepTol = 1e-12
+	plain := NelderMead(rosenbrock, []float64{-1.2, 1.0}, &opts)
+	if plain.Restarts != 0 {
+		t.Errorf("restarts = %d without RestartEvery, want 0", plain.Restarts)
+	}
+
+	opts.RestartEvery = 50
+	restarted := NelderMead(rosenbrock, []float64{-1.2, 1.0}, &opts)
+	if !restarted.Converged {
+		t.Fatalf("expected convergence, got: %s", restarted.Message)
+	}
+	if restarted.Restarts == 0 {
+		t.Error("expected at least one restart")
+	}
+	if restarted.Fun > plain.Fun {
+		t.Errorf("fun with restarts = %v, want <= %v", restarted.Fun, plain.Fun)
+	}
+}
+
+func TestNelderMead_RestartEveryScaled(t *testing.T) {
+	// The second coordinate lives in units a thousand times larger.
+	f := func(x []float64) float64 {
+		return (x[0]-1)*(x[0]-1) + (x[1]-2000)*(x[1]-2000)/1e6
+	}
+	opts := DefaultNelderMeadOptions()
+	opts.Scale = []float64{1, 1000}
+	opts.RestartEvery = 20
+	opts.MaxIterations = 2000
+	result := NelderMead(f, []float64{0, 0}, &opts)
+	if !result.Converged {
+		t.Fatalf("expected convergence, got: %s", result.Message)
+	}
+	if result.Restarts == 0 {
+		t.Error("expected at least one restart")
+	}
+	if !approxEqual(result.X[0], 1, 1e-2) || !approxEqual(result.X[1], 2000, 10) {
+		t.Errorf("x = %v, want about [1, 2000]", result.X)
+	}
+
+	// The rebuilt simplex keeps the scaled diameter in every coordinate.
+	rebuilt := createScaledSimplex([]float64{3, 4000}, 0.5, opts.Scale)
+	for i := 1; i < len(rebuilt); i++ {
+		if d := NormScaled(Sub(rebuilt[i], rebuilt[0]), opts.Scale); !approxEqual(d, 0.5, tol) {
+			t.Errorf("edge %d scaled length = %v, want 0.5", i, d)
+		}
+	}
+
+	// A rebuild iteration still takes a step: besides the 2 new vertices it
+	// evaluates at least one trial point.
+	calls := 0
+	counted := func(x []float64) float64 {
+		calls++
+		return f(x)
+	}
+	record := &callsAt{calls: &calls, seen: map[int]int{}}
+	opts.MaxIterations = 12
+	opts.RestartEvery = 5
+	opts.FuncTol, opts.StepTol = 0, 0
+	opts.Converger = record
+	short := NelderMead(counted, []float64{0, 0}, &opts)
+	if short.Iterations != 12 || short.Restarts != 2 {
+		t.Fatalf("iterations, restarts = %d, %d; want 12, 2", short.Iterations, short.Restarts)
+	}
+	for _, it := range []int{5, 10} {
+		if d := record.seen[it] - record.seen[it-1]; d < 3 {
+			t.Errorf("iteration %d made %d calls, want at least 3", it, d)
+		}
+	}
+}
+
+// callsAt is a Converger that records a call counter at each iteration.
+type callsAt struct {
+	calls *int
+	seen  map[int]int
+}
+
+func (c *callsAt) Check(state IterationState) *ConvergenceReason {
+	c.seen[state.Iteration] = *c.calls
+	return nil
+}
+
+// stopAt is a Converger that stops once a given iteration count is reached.
+type stopAt struct {
+	iteration int
+	seen      []IterationState
+}
+
+func (c *stopAt) Check(state IterationState) *ConvergenceReason {
+	c.seen = append(c.seen, state)
+	if state.Iteration >= c.iteration {
+		return &ConvergenceReason{Kind: "custom", Message: fmt.Sprintf("Stopped: custom rule at iteration %d", state.Iteration)}
+	}
+	return nil
+}
+
+func TestNelderMead_CustomConverger(t *testing.T) {
+	c := &stopAt{iteration: 7}
+	opts := DefaultNelderMeadOptions()
+	opts.Converger = c
+	result := NelderMead(rosenbrock, []float64{-1.2, 1.0}, &opts)
+	if result.Iterations != 7 {
+		t.Errorf("iterations = %d, want 7", result.Iterations)
+	}
+	if result.Converged {
+		t.Error("custom reason kind should not count as converged")
+	}
+	if result.Message != "Stopped: custom rule at iteration 7" {
+		t.Errorf("message = %q, want the converger's message", result.Message)
+	}
+	for _, s := range c.seen {
+		if s.Best > s.Worst || s.Diameter <= 0 || s.Spread < 0 {
+			t.Errorf("inconsistent state %+v", s)
+		}
+	}
+}
+
 func TestDefaultNelderMeadOptions(t *testing.T) {
 	opts := DefaultNelderMeadOptions()
 	if opts.Alpha != 1.0 {
@@ -425,6 +1121,9 @@ func TestDefaultNelderMeadOptions(t *testing.T) {
 	if opts.Sigma != 0.5 {
 		t.Errorf("Sigma = %v, want 0.5", opts.Sigma)
 	}
+	if !opts.ShrinkAll {
+		t.Error("ShrinkAll should default to true")
+	}
 	if opts.InitialSimplexScale != 0.05 {
 		t.Errorf("InitialSimplexScale = %v, want 0.05", opts.InitialSimplexScale)
 	}
@@ -432,3 +1131,304 @@ func TestDefaultNelderMeadOptions(t *testing.T) {
 		t.Errorf("MaxIterations = %v, want 1000", opts.MaxIterations)
 	}
 }
+
+func TestMultiStartRandom_SameSeedReproducible(t *testing.T) {
+	lo := []float64{-5, -5}
+	hi := []float64{5, 5}
+	run := func(seed int64) OptimizeResult {
+		opts := DefaultNelderMeadOptions()
+		opts.RNG = rand.New(rand.NewSource(seed))
+		return MultiStartRandom(himmelblau, lo, hi, 4, &opts)
+	}
+	a, b := run(42), run(42)
+	if a.Fun != b.Fun || a.FunctionCalls != b.FunctionCalls {
+		t.Errorf("same seed gave different runs: fun %v vs %v, calls %d vs %d", a.Fun, b.Fun, a.FunctionCalls, b.FunctionCalls)
+	}
+	sliceEqual(t, a.X, b.X, tol)
+
+	// The default RNG is seeded too, so nil options are reproducible.
+	c := MultiStartRandom(himmelblau, lo, hi, 4, nil)
+	d := MultiStartRandom(himmelblau, lo, hi, 4, nil)
+	if c.Fun != d.Fun {
+		t.Errorf("default RNG not reproducible: %v vs %v", c.Fun, d.Fun)
+	}
+	if a.Fun >= 1e-6 {
+		t.Errorf("fun = %v, want < 1e-6", a.Fun)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// line-search tests
+// ---------------------------------------------------------------------------
+
+func TestBacktrackingLineSearch_Sphere(t *testing.T) {
+	x := []float64{5, 5}
+	g := sphereGrad(x)
+	ls := BacktrackingLineSearch(sphere, x, Negate(g), sphere(x), g)
+	if !ls.Success {
+		t.Fatal("expected success")
+	}
+	if ls.FNew >= sphere(x) {
+		t.Errorf("fNew = %v, want < %v", ls.FNew, sphere(x))
+	}
+	if ls.FunctionCalls < 1 {
+		t.Errorf("functionCalls = %d, want >= 1", ls.FunctionCalls)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// bfgs tests
+// ---------------------------------------------------------------------------
+
+func TestBFGS_Sphere(t *testing.T) {
+	result := BFGS(sphere, sphereGrad, []float64{5, 5}, nil)
+	if !result.Converged {
+		t.Fatalf("expected convergence, got: %s", result.Message)
+	}
+	if result.Fun >= 1e-8 {
+		t.Errorf("fun = %v, want < 1e-8", result.Fun)
+	}
+	nm := NelderMead(sphere, []float64{5, 5}, nil)
+	if result.Iterations >= nm.Iterations {
+		t.Errorf("BFGS iterations = %d, want fewer than Nelder-Mead's %d", result.Iterations, nm.Iterations)
+	}
+}
+
+func TestBFGS_Rosenbrock(t *testing.T) {
+	result := BFGS(rosenbrock, rosenbrockGrad, []float64{-1.2, 1.0}, nil)
+	if !result.Converged {
+		t.Fatalf("expected convergence, got: %s", result.Message)
+	}
+	if !approxEqual(result.X[0], 1, 1e-4) || !approxEqual(result.X[1], 1, 1e-4) {
+		t.Errorf("x = %v, want near [1,1]", result.X)
+	}
+	opts := DefaultNelderMeadOptions()
+	opts.MaxIterations = 5000
+	opts.FuncTol = 1e-15
+	opts.StepTol = 1e-12
+	nm := NelderMead(rosenbrock, []float64{-1.2, 1.0}, &opts)
+	if result.Iterations >= nm.Iterations {
+		t.Errorf("BFGS iterations = %d, want fewer than Nelder-Mead's %d", result.Iterations, nm.Iterations)
+	}
+}
+
+func TestBFGS_AlreadyAtMinimum(t *testing.T) {
+	result := BFGS(sphere, sphereGrad, []float64{0, 0}, nil)
+	if !result.Converged || result.Iterations != 0 {
+		t.Errorf("converged = %v, iterations = %d; want true, 0", result.Converged, result.Iterations)
+	}
+	if result.Gradient == nil {
+		t.Error("gradient should be populated for BFGS")
+	}
+}
+
+func TestBFGS_RespectsMaxIterations(t *testing.T) {
+	opts := DefaultOptions()
+	opts.MaxIterations = 3
+	result := BFGS(rosenbrock, rosenbrockGrad, []float64{-1.2, 1.0}, &opts)
+	if result.Converged {
+		t.Error("should not converge with maxIterations=3")
+	}
+	if result.Iterations > 3 {
+		t.Errorf("iterations = %d, want <= 3", result.Iterations)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// finite-diff tests
+// ---------------------------------------------------------------------------
+
+func TestCentralDiffGradient(t *testing.T) {
+	sliceEqual(t, CentralDiffGradient(sphere, []float64{3, -4}), []float64{6, -8}, 1e-8)
+	x := []float64{-1.2, 1.0}
+	sliceEqual(t, CentralDiffGradient(rosenbrock, x), rosenbrockGrad(x), 1e-6)
+}
+
+func TestGradCheck(t *testing.T) {
+	x := []float64{-1.2, 1.0}
+	if maxErr, ok := GradCheck(rosenbrock, rosenbrockGrad, x, 1e-6); !ok {
+		t.Errorf("correct gradient rejected: maxErr = %v", maxErr)
+	}
+
+	wrongGrad := func(x []float64) []float64 {
+		g := rosenbrockGrad(x)
+		g[1] *= 2 // dropped factor
+		return g
+	}
+	if maxErr, ok := GradCheck(rosenbrock, wrongGrad, x, 1e-6); ok || maxErr < 0.5 {
+		t.Errorf("wrong gradient accepted: maxErr = %v, ok = %v", maxErr, ok)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// gradient-descent tests
+// ---------------------------------------------------------------------------
+
+func TestGradientDescent_Sphere(t *testing.T) {
+	calls := 0
+	counted := func(x []float64) float64 {
+		calls++
+		return sphere(x)
+	}
+	result := GradientDescent(counted, []float64{5, 5}, nil)
+	if !result.Converged {
+		t.Fatalf("expected convergence, got: %s", result.Message)
+	}
+	if !approxEqual(result.X[0], 0, 1e-6) || !approxEqual(result.X[1], 0, 1e-6) {
+		t.Errorf("x = %v, want near [0,0]", result.X)
+	}
+	if result.GradientCalls == 0 {
+		t.Error("gradientCalls should be nonzero")
+	}
+	if result.FunctionCalls != calls {
+		t.Errorf("functionCalls = %d, want %d (actual evaluations)", result.FunctionCalls, calls)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// multi-start tests
+// ---------------------------------------------------------------------------
+
+func TestMultiStart_Himmelblau(t *testing.T) {
+	starts := [][]float64{{-5, -5}, {5, -5}, {-5, 5}, {5, 5}}
+	result := MultiStart(himmelblau, starts, nil)
+
+	bestFun := math.Inf(1)
+	totalCalls := 0
+	for _, x0 := range starts {
+		r := NelderMead(himmelblau, x0, nil)
+		totalCalls += r.FunctionCalls
+		bestFun = math.Min(bestFun, r.Fun)
+	}
+
+	if result.Fun != bestFun {
+		t.Errorf("fun = %v, want best of all runs %v", result.Fun, bestFun)
+	}
+	if result.Fun >= 1e-6 {
+		t.Errorf("fun = %v, want < 1e-6", result.Fun)
+	}
+	if result.FunctionCalls != totalCalls {
+		t.Errorf("functionCalls = %d, want total %d", result.FunctionCalls, totalCalls)
+	}
+}
+
+func TestMultiStart_InvalidFirstStart(t *testing.T) {
+	starts := [][]float64{{math.NaN(), 0}, {-5, -5}, {5, 5}}
+	result := MultiStart(himmelblau, starts, nil)
+
+	if math.IsNaN(result.Fun) {
+		t.Fatalf("fun = NaN, want the best valid run (message %q)", result.Message)
+	}
+	if result.Fun >= 1e-6 {
+		t.Errorf("fun = %v, want < 1e-6", result.Fun)
+	}
+	if !result.Converged {
+		t.Errorf("converged = false, want true")
+	}
+}
+
+// ---------------------------------------------------------------------------
+// coordinate-descent tests
+// ---------------------------------------------------------------------------
+
+func TestCoordinateDescent_AxisAlignedQuadratic(t *testing.T) {
+	separable := func(x []float64) float64 {
+		a := x[0] - 1
+		b := x[1] + 2
+		return a*a + 10*b*b
+	}
+	result := CoordinateDescent(separable, []float64{5, 5}, nil)
+	if !result.Converged {
+		t.Fatalf("expected convergence, got: %s", result.Message)
+	}
+	if !approxEqual(result.X[0], 1, 1e-6) || !approxEqual(result.X[1], -2, 1e-6) {
+		t.Errorf("x = %v, want near [1,-2]", result.X)
+	}
+	if result.Iterations > 3 {
+		t.Errorf("iterations = %d, want <= 3 sweeps on a separable problem", result.Iterations)
+	}
+	if result.GradientCalls != 0 || result.Gradient != nil {
+		t.Error("coordinate descent is derivative-free")
+	}
+}
+
+func TestCoordinateDescent_Booth(t *testing.T) {
+	result := CoordinateDescent(booth, []float64{0, 0}, nil)
+	if !approxEqual(result.X[0], 1, 1e-3) || !approxEqual(result.X[1], 3, 1e-3) {
+		t.Errorf("x = %v, want near [1,3]", result.X)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// powell tests
+// ---------------------------------------------------------------------------
+
+func TestPowell_Rosenbrock(t *testing.T) {
+	result := Powell(rosenbrock, []float64{-1.2, 1.0}, nil)
+	if !result.Converged {
+		t.Fatalf("expected convergence, got: %s", result.Message)
+	}
+	if !approxEqual(result.X[0], 1, 1e-4) || !approxEqual(result.X[1], 1, 1e-4) {
+		t.Errorf("x = %v, want near [1,1]", result.X)
+	}
+
+	opts := DefaultNelderMeadOptions()
+	opts.MaxIterations = 5000
+	opts.FuncTol = 1e-15
+	opts.StepTol = 1e-12
+	nm := NelderMead(rosenbrock, []float64{-1.2, 1.0}, &opts)
+	if result.Fun > nm.Fun {
+		t.Errorf("Powell fun = %v, want <= Nelder-Mead's %v", result.Fun, nm.Fun)
+	}
+	// On the curved valley Powell's line searches cost about 2x Nelder-Mead's
+	// evaluations for a far more accurate minimum; golden-section line
+	// searches needed about 7x.
+	if result.FunctionCalls > 3*nm.FunctionCalls {
+		t.Errorf("Powell functionCalls = %d, want <= 3x Nelder-Mead's %d", result.FunctionCalls, nm.FunctionCalls)
+	}
+}
+
+func TestPowell_QuadraticTermination(t *testing.T) {
+	// On a quadratic, conjugate directions reach the minimum in about n+1
+	// line searches, and Brent's parabolic step makes each one cheap, so
+	// Powell should beat Nelder-Mead outright.
+	result := Powell(booth, []float64{0, 0}, nil)
+	if !result.Converged {
+		t.Fatalf("expected convergence, got: %s", result.Message)
+	}
+	if result.Iterations > 3 {
+		t.Errorf("iterations = %d, want <= 3 on a quadratic", result.Iterations)
+	}
+	nm := NelderMead(booth, []float64{0, 0}, nil)
+	if result.FunctionCalls >= nm.FunctionCalls {
+		t.Errorf("Powell functionCalls = %d, want fewer than Nelder-Mead's %d", result.FunctionCalls, nm.FunctionCalls)
+	}
+	if result.Fun > nm.Fun {
+		t.Errorf("Powell fun = %v, want <= Nelder-Mead's %v", result.Fun, nm.Fun)
+	}
+}
+
+func TestLineMinimize_Quadratic(t *testing.T) {
+	// A parabolic step lands on a parabola's vertex, so past the bracket
+	// the refinement needs only a few evaluations.
+	q := func(x []float64) float64 { return (x[0]-2.7)*(x[0]-2.7) + 1 }
+	for _, x0 := range []float64{0, -5, 100} {
+		step, ft, calls := lineMinimize(q, []float64{x0}, []float64{1}, q([]float64{x0}))
+		if !approxEqual(x0+step, 2.7, 1e-9) || ft != 1 {
+			t.Errorf("x0=%v: minimum at %v with f=%v, want 2.7 with f=1", x0, x0+step, ft)
+		}
+		if calls > 16 {
+			t.Errorf("x0=%v: calls = %d, want <= 16", x0, calls)
+		}
+	}
+}
+
+func TestPowell_Sphere(t *testing.T) {
+	result := Powell(sphere, []float64{5, 5}, nil)
+	if !result.Converged {
+		t.Fatalf("expected convergence, got: %s", result.Message)
+	}
+	if result.Fun >= 1e-12 {
+		t.Errorf("fun = %v, want < 1e-12", result.Fun)
+	}
+}
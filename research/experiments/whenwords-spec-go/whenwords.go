@@ -5,114 +5,299 @@ package whenwords
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"math"
 	"regexp"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
 // TimeAgo converts a Unix timestamp to a relative time string like "3 hours ago" or "in 2 days".
 func TimeAgo(timestamp, reference int64) string {
-	diff := reference - timestamp
-	future := diff < 0
-	seconds := diff
-	if seconds < 0 {
-		seconds = -seconds
-	}
+	return TimeAgoLocale(timestamp, reference, english)
+}
+
+// TimeAgoNanos is TimeAgo for Unix timestamps in nanoseconds. Fractions of
+// a second are dropped, so sub-second differences read "just now".
+func TimeAgoNanos(timestampNanos, referenceNanos int64) string {
+	return TimeAgoT(time.Unix(0, timestampNanos), time.Unix(0, referenceNanos))
+}
 
-	var value int64
-	var unit string
+// HumanDateNanos is HumanDate for Unix timestamps in nanoseconds.
+func HumanDateNanos(timestampNanos, referenceNanos int64) string {
+	return HumanDateT(time.Unix(0, timestampNanos).UTC(), time.Unix(0, referenceNanos).UTC())
+}
 
+// TimeUntil phrases target relative to now for upcoming events: "in 3
+// hours" while it is ahead, "now" within TimeAgo's "just now" window, and
+// "passed" once it is further behind.
+func TimeUntil(target, now int64) string {
+	_, _, future, justNow := TimeAgoParts(target, now)
 	switch {
-	case seconds <= 44:
-		return "just now"
-	case seconds <= 89:
-		value = 1
-		unit = "minute"
-	case seconds <= 2640:
-		value = int64(math.Round(float64(seconds) / 60))
-		unit = "minute"
-	case seconds <= 5340:
-		value = 1
-		unit = "hour"
-	case seconds <= 75600:
-		value = int64(math.Round(float64(seconds) / 3600))
-		unit = "hour"
-	case seconds <= 126000:
-		value = 1
-		unit = "day"
-	case seconds <= 2160000:
-		value = int64(math.Round(float64(seconds) / 86400))
-		unit = "day"
-	case seconds <= 3888000:
-		value = 1
-		unit = "month"
-	case seconds <= 27561600:
-		value = int64(math.Round(float64(seconds) / 2592000))
-		unit = "month"
-	case seconds <= 47260800:
-		value = 1
-		unit = "year"
-	default:
-		value = int64(math.Round(float64(seconds) / 31536000))
-		unit = "year"
+	case justNow:
+		return "now"
+	case !future:
+		return "passed"
 	}
+	return TimeAgo(target, now)
+}
+
+// TimeAgoOptions configures TimeAgoWith. The zero value matches TimeAgo.
+type TimeAgoOptions struct {
+	UseWeeks bool // Render differences of 7–27 days as "1 week", "2 weeks", ...
+
+	// MaxUnit caps the unit ("minute", "hour", "day", "week", "month");
+	// larger differences are counted in it, e.g. "400 days ago". Empty means
+	// no cap.
+	MaxUnit string
+
+	UseArticles bool // Render a single unit as "an hour ago" rather than "1 hour ago"
+
+	Thresholds Thresholds // Unit cutoffs (default: DefaultThresholds())
+
+	DayWords bool // Render "1 day ago"/"in 1 day" as "yesterday"/"tomorrow"
 
-	// Pluralize
-	if value != 1 {
-		unit += "s"
+	// JustNowText and SoonText replace "just now" for differences below the
+	// first threshold in the past and future respectively, e.g. "moments
+	// ago" and "in a moment". Empty keeps "just now".
+	JustNowText string
+	SoonText    string
+
+	// Pluralize, if set, returns the unit word for a count, overriding the
+	// English "+s" rule.
+	Pluralize func(unit string, n int) string
+
+	Case Case // Letter case of the result (default: lowercase, "3 hours ago")
+}
+
+// Case selects the letter case of a relative phrase. The zero value keeps
+// each formatter's usual form: lowercase for TimeAgo, capitalized for
+// HumanDate.
+type Case int
+
+const (
+	CaseDefault  Case = iota // The formatter's usual case
+	CaseLower                // "today", "last Saturday", "3 hours ago"
+	CaseSentence             // "Today", "Last Saturday", "3 hours ago", "Just now"
+	CaseTitle                // "Today", "Last Saturday", "3 Hours Ago"
+)
+
+// apply recases s. Only the first letter of a word changes, and month and
+// weekday names found in names keep the table's own case, so "January 5"
+// stays as it is under CaseLower.
+func (c Case) apply(s string, names *Names) string {
+	if c == CaseDefault {
+		return s
+	}
+	words := strings.Split(s, " ")
+	for i, w := range words {
+		if w == "" || (i > 0 && c != CaseTitle) || names.has(strings.TrimRight(w, ",")) {
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(w)
+		if c == CaseLower {
+			r = unicode.ToLower(r)
+		} else {
+			r = unicode.ToUpper(r)
+		}
+		words[i] = string(r) + w[size:]
 	}
+	return strings.Join(words, " ")
+}
 
-	if future {
-		return fmt.Sprintf("in %d %s", value, unit)
+// article returns the English indefinite article for a unit name. "hour"
+// takes "an" because its h is silent.
+func article(unit string) string {
+	if unit == "hour" || strings.ContainsRune("aeiou", rune(unit[0])) {
+		return "an"
 	}
-	return fmt.Sprintf("%d %s ago", value, unit)
+	return "a"
 }
 
-// Duration formats a number of seconds as a human-readable duration string.
-// compact uses abbreviated units ("2h 30m" vs "2 hours, 30 minutes").
-// maxUnits controls the maximum number of units to display (default-like: 2).
-// Panics on negative seconds.
-func Duration(seconds int, compact bool, maxUnits int) string {
-	if seconds < 0 {
-		panic("duration seconds must be non-negative")
+// relativeUnitSeconds gives the length of each TimeAgo unit in seconds.
+var relativeUnitSeconds = map[string]int64{
+	"minute": 60,
+	"hour":   3600,
+	"day":    86400,
+	"week":   604800,
+	"month":  2592000,
+	"year":   31536000,
+}
+
+// TimeAgoWith is TimeAgo with formatting options applied.
+func TimeAgoWith(timestamp, reference int64, opts TimeAgoOptions) string {
+	return opts.Case.apply(timeAgo(reference-timestamp, english, opts), nil)
+}
+
+// TimeAgoT is TimeAgo for time.Time values. Only the instants matter, so
+// the locations of t and now do not affect the result.
+func TimeAgoT(t, now time.Time) string {
+	return timeAgo(int64(now.Sub(t)/time.Second), english, TimeAgoOptions{})
+}
+
+// Threshold is one tier of the TimeAgo table: differences of up to Max
+// seconds are shown in Unit, as the fixed Value if it is nonzero and
+// otherwise as the difference rounded to whole units. An empty Unit means
+// "just now".
+type Threshold struct {
+	Max   int64
+	Unit  string
+	Value int64
+}
+
+// Thresholds is a TimeAgo table, ordered by increasing Max.
+type Thresholds []Threshold
+
+// DefaultThresholds returns a copy of the table TimeAgo uses, which callers
+// may adjust and pass as TimeAgoOptions.Thresholds.
+func DefaultThresholds() Thresholds {
+	return slices.Clone(defaultThresholds)
+}
+
+// defaultThresholds is the table TimeAgo uses.
+var defaultThresholds = Thresholds{
+	{44, "", 0},
+	{89, "minute", 1},
+	{2640, "minute", 0},
+	{5340, "hour", 1},
+	{75600, "hour", 0},
+	{126000, "day", 1},
+	{2160000, "day", 0},
+	{3888000, "month", 1},
+	{27561600, "month", 0},
+	{47260800, "year", 1},
+	{math.MaxInt64, "year", 0},
+}
+
+// valid reports whether every tier names a unit TimeAgo can count in:
+// "minute", "hour", "day", "week", "month", "year", or "" for "just now".
+func (thresholds Thresholds) valid() bool {
+	for _, t := range thresholds {
+		if _, ok := relativeUnitSeconds[t.Unit]; !ok && t.Unit != "" {
+			return false
+		}
 	}
+	return true
+}
 
-	type unitDef struct {
-		secs    int
-		verbose string
-		abbrev  string
+// orDefault returns thresholds, or the default table if thresholds is empty
+// or invalid.
+func (thresholds Thresholds) orDefault() Thresholds {
+	if len(thresholds) == 0 || !thresholds.valid() {
+		return defaultThresholds
 	}
+	return thresholds
+}
 
-	units := []unitDef{
-		{31536000, "year", "y"},
-		{2592000, "month", "mo"},
-		{86400, "day", "d"},
-		{3600, "hour", "h"},
-		{60, "minute", "m"},
-		{1, "second", "s"},
+// weekCutoff returns the difference beyond which UseWeeks may replace the
+// table's unit: the end of the last tier that shows less than two days
+// (126000 seconds, "1 day", in the default table).
+func (thresholds Thresholds) weekCutoff() int64 {
+	var cutoff int64
+	for _, t := range thresholds.orDefault() {
+		if relativeUnitSeconds[t.Unit] < 86400 || t.Unit == "day" && t.Value == 1 {
+			cutoff = max(cutoff, t.Max)
+		}
 	}
+	return cutoff
+}
 
-	if seconds == 0 {
-		if compact {
-			return "0s"
+// TimeAgoWithThresholds is TimeAgo using a custom threshold table. A table
+// naming an unknown unit is ignored in favor of the default table.
+func TimeAgoWithThresholds(timestamp, reference int64, thresholds Thresholds) string {
+	return TimeAgoWith(timestamp, reference, TimeAgoOptions{Thresholds: thresholds})
+}
+
+// relativeUnit picks the display value and singular unit name for an absolute
+// difference in seconds. ok is false when the difference rounds to "just now".
+// Differences beyond the last tier use the last tier. An empty or invalid
+// table means the default table.
+func relativeUnit(seconds int64, thresholds Thresholds) (value int64, unit string, ok bool) {
+	thresholds = thresholds.orDefault()
+	tier := thresholds[len(thresholds)-1]
+	for _, t := range thresholds {
+		if seconds <= t.Max {
+			tier = t
+			break
 		}
-		return "0 seconds"
 	}
 
-	// Decompose into units
-	type part struct {
-		value int
-		unit  unitDef
+	switch {
+	case tier.Unit == "":
+		return 0, "", false
+	case tier.Value != 0:
+		return tier.Value, tier.Unit, true
+	default:
+		return int64(math.Round(float64(seconds) / float64(relativeUnitSeconds[tier.Unit]))), tier.Unit, true
+	}
+}
+
+// durationUnit is one step of the Duration decomposition. size is measured
+// in the base unit of the table it belongs to.
+type durationUnit struct {
+	size    int64
+	verbose string
+	abbrev  string
+}
+
+// durationUnits is measured in seconds.
+var durationUnits = []durationUnit{
+	{31536000, "year", "y"},
+	{2592000, "month", "mo"},
+	{86400, "day", "d"},
+	{3600, "hour", "h"},
+	{60, "minute", "m"},
+	{1, "second", "s"},
+}
+
+// fineDurationUnits is measured in nanoseconds and extends durationUnits
+// below the second.
+var fineDurationUnits = func() []durationUnit {
+	var units []durationUnit
+	for _, u := range durationUnits {
+		units = append(units, durationUnit{u.size * int64(time.Second), u.verbose, u.abbrev})
 	}
-	var parts []part
-	remaining := seconds
+	return append(units,
+		durationUnit{int64(time.Millisecond), "millisecond", "ms"},
+		durationUnit{int64(time.Microsecond), "microsecond", "us"},
+		durationUnit{int64(time.Nanosecond), "nanosecond", "ns"},
+	)
+}()
+
+// durationPart is a value paired with the unit it counts.
+type durationPart struct {
+	value int64
+	unit  durationUnit
+}
+
+// RoundMode controls how Duration adjusts the last kept unit when maxUnits
+// drops smaller ones.
+type RoundMode int
+
+const (
+	Nearest RoundMode = iota // Round half up (the default)
+	Floor                    // Drop the remainder
+	Ceil                     // Round up on any remainder
+)
+
+// decompose splits amount into at most maxUnits non-zero parts, largest unit
+// first, adjusting the last kept part from the discarded remainder per mode.
+// As the Duration spec requires, a rounded unit does not carry, so 3599
+// seconds to one unit is "60 minutes".
+func decompose(amount int64, units []durationUnit, maxUnits int, mode RoundMode) []durationPart {
+	var parts []durationPart
+	remaining := amount
 	for _, u := range units {
-		if remaining >= u.secs {
-			v := remaining / u.secs
-			remaining = remaining % u.secs
-			parts = append(parts, part{v, u})
+		if remaining >= u.size {
+			v := remaining / u.size
+			remaining = remaining % u.size
+			parts = append(parts, durationPart{v, u})
 		}
 	}
 
@@ -122,171 +307,1297 @@ func Duration(seconds int, compact bool, maxUnits int) string {
 		lastIdx := maxUnits - 1
 		lastUnit := parts[lastIdx]
 
-		// Calculate the total remaining seconds after the last kept unit
-		remainderSecs := 0
+		// Calculate the total remainder after the last kept unit
+		var remainder int64
 		for i := maxUnits; i < len(parts); i++ {
-			remainderSecs += parts[i].value * parts[i].unit.secs
+			remainder += parts[i].value * parts[i].unit.size
 		}
 
-		// Round: if remainder >= half of the last unit's seconds, round up
-		if remainderSecs*2 >= lastUnit.unit.secs {
-			parts[lastIdx].value++
+		// Round: by default, if remainder >= half of the last unit's size, round up
+		switch mode {
+		case Nearest:
+			if remainder*2 >= lastUnit.unit.size {
+				parts[lastIdx].value++
+			}
+		case Ceil:
+			if remainder > 0 {
+				parts[lastIdx].value++
+			}
 		}
 
 		parts = parts[:maxUnits]
 	}
+	return parts
+}
+
+// decomposeCarry is decompose for the relative formatters: a round up that
+// fills a larger unit carries into it, so 1h 59m 59s to two units reads
+// "2 hours" rather than "1 hour, 60 minutes". maxUnits below 1 is treated
+// as 1.
+func decomposeCarry(amount int64, units []durationUnit, maxUnits int, mode RoundMode) []durationPart {
+	maxUnits = max(maxUnits, 1)
+	parts := decompose(amount, units, maxUnits, mode)
+	var rounded int64
+	for _, p := range parts {
+		rounded += p.value * p.unit.size
+	}
+	if rounded == amount {
+		return parts
+	}
+	return decompose(rounded, units, maxUnits, Floor)
+}
+
+// verbose renders the part as "2 hours".
+func (p durationPart) verbose() string {
+	name := p.unit.verbose
+	if p.value != 1 {
+		name += "s"
+	}
+	return fmt.Sprintf("%d %s", p.value, name)
+}
+
+// Duration formats a number of seconds as a human-readable duration string.
+// compact uses abbreviated units ("2h 30m" vs "2 hours, 30 minutes").
+// maxUnits controls the maximum number of units to display (default-like: 2).
+// Panics on negative seconds.
+func Duration(seconds int, compact bool, maxUnits int) string {
+	if seconds < 0 {
+		panic("duration seconds must be non-negative")
+	}
+	return formatParts(decompose(int64(seconds), durationUnits, maxUnits, Nearest), DurationOptions{Compact: compact})
+}
+
+// DurationComponent is one nonzero unit of a decomposed duration.
+type DurationComponent struct {
+	Unit  string // Singular unit name: "year", "month", "day", "hour", "minute", "second"
+	Value int
+}
+
+// DurationParts returns every nonzero unit of seconds, largest first, using
+// the same decomposition as Duration without truncation. Panics on negative
+// seconds.
+func DurationParts(seconds int) []DurationComponent {
+	if seconds < 0 {
+		panic("duration seconds must be non-negative")
+	}
+	var out []DurationComponent
+	for _, p := range decompose(int64(seconds), durationUnits, len(durationUnits), Nearest) {
+		out = append(out, DurationComponent{p.unit.verbose, int(p.value)})
+	}
+	return out
+}
+
+// DurationBetween formats the interval between two timestamps with Duration,
+// in either order.
+func DurationBetween(start, end int64, compact bool, maxUnits int) string {
+	if start > end {
+		start, end = end, start
+	}
+	return Duration(int(end-start), compact, maxUnits)
+}
+
+// DurationClock formats seconds as a timer reading: "M:SS" under an hour
+// ("0:09", "1:05") and "H:MM:SS" from an hour up ("1:02:05"), with the
+// leading field growing as needed. Panics on negative seconds.
+func DurationClock(seconds int) string {
+	if seconds < 0 {
+		panic("duration seconds must be non-negative")
+	}
+	h, m, sec := seconds/3600, seconds%3600/60, seconds%60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, sec)
+	}
+	return fmt.Sprintf("%d:%02d", m, sec)
+}
+
+// SignedDuration is Duration for values that may be negative, such as
+// overruns: negative seconds are formatted by magnitude and prefixed "-".
+func SignedDuration(seconds int, compact bool, maxUnits int) string {
+	if seconds < 0 {
+		return "-" + Duration(-seconds, compact, maxUnits)
+	}
+	return Duration(seconds, compact, maxUnits)
+}
+
+// DurationOptions configures DurationWith.
+type DurationOptions struct {
+	Compact  bool // Abbreviated units ("2h 30m")
+	MaxUnits int  // Maximum units to display (default 2; ignored with AllUnits)
+	AllUnits bool // Display every nonzero unit without truncation
+	Fuzzy    bool // Round to one unit, prefixed "about" unless exact
+
+	RoundMode RoundMode // How dropped units adjust the last kept one
+
+	UseArticles bool // Render a single unit as "an hour" rather than "1 hour"
+
+	Separator        string // Joins parts (default ", ", or " " when Compact)
+	FinalConjunction string // Joins the last two parts, e.g. "and" gives "1 hour and 30 minutes"
+
+	// Pluralize, if set, returns the unit word for a count in verbose
+	// output, overriding the English "+s" rule.
+	Pluralize func(unit string, n int) string
+}
+
+// DurationWith is Duration with formatting options applied. Panics on
+// negative seconds.
+func DurationWith(seconds int, opts DurationOptions) string {
+	if seconds < 0 {
+		panic("duration seconds must be non-negative")
+	}
+	maxUnits := opts.MaxUnits
+	switch {
+	case opts.AllUnits:
+		maxUnits = len(durationUnits)
+	case maxUnits <= 0:
+		maxUnits = 2
+	}
+
+	if !opts.Fuzzy {
+		return formatParts(decompose(int64(seconds), durationUnits, maxUnits, opts.RoundMode), opts)
+	}
+
+	parts := decompose(int64(seconds), durationUnits, 1, opts.RoundMode)
+	if len(parts) == 0 {
+		return formatParts(parts, opts)
+	}
+	// Re-decompose so rounding up to a full larger unit reads "1 hour",
+	// not "60 minutes".
+	rounded := parts[0].value * parts[0].unit.size
+	out := formatParts(decompose(rounded, durationUnits, 1, Floor), opts)
+	if rounded != int64(seconds) {
+		out = "about " + out
+	}
+	return out
+}
+
+// DurationFine is Duration for a time.Duration, adding millisecond,
+// microsecond, and nanosecond units ("1m 500ms"). Sub-second amounts are
+// shown rather than rounded to whole seconds; rounding only happens when
+// maxUnits drops smaller units. Panics on negative durations.
+func DurationFine(d time.Duration, compact bool, maxUnits int) string {
+	if d < 0 {
+		panic("duration must be non-negative")
+	}
+	return formatParts(decompose(int64(d), fineDurationUnits, maxUnits, Nearest), DurationOptions{Compact: compact})
+}
+
+// formatParts joins decomposed parts in the style opts selects. An empty
+// decomposition renders as zero seconds.
+func formatParts(parts []durationPart, opts DurationOptions) string {
+	compact := opts.Compact
+	if len(parts) == 0 {
+		switch {
+		case compact:
+			return "0s"
+		case opts.Pluralize != nil:
+			return "0 " + opts.Pluralize("second", 0)
+		}
+		return "0 seconds"
+	}
 
 	// Build output
 	var strs []string
 	for _, p := range parts {
 		if compact {
 			strs = append(strs, fmt.Sprintf("%d%s", p.value, p.unit.abbrev))
+		} else if opts.Pluralize != nil {
+			strs = append(strs, fmt.Sprintf("%d %s", p.value, opts.Pluralize(p.unit.verbose, int(p.value))))
+		} else if opts.UseArticles && p.value == 1 {
+			strs = append(strs, article(p.unit.verbose)+" "+p.unit.verbose)
 		} else {
-			name := p.unit.verbose
-			if p.value != 1 {
-				name += "s"
-			}
-			strs = append(strs, fmt.Sprintf("%d %s", p.value, name))
+			strs = append(strs, p.verbose())
+		}
+	}
+
+	sep := opts.Separator
+	if sep == "" {
+		sep = ", "
+		if compact {
+			sep = " "
 		}
 	}
+	if opts.FinalConjunction != "" && len(strs) > 1 {
+		last := len(strs) - 1
+		return strings.Join(strs[:last], sep) + " " + opts.FinalConjunction + " " + strs[last]
+	}
+	return strings.Join(strs, sep)
+}
+
+// TimeAgoPrecise is TimeAgo using up to maxUnits units, e.g.
+// "2 hours 15 minutes ago". Only identical timestamps read as "just now".
+// Unlike Duration, a rounded unit carries, so 7199 seconds to two units is
+// "2 hours ago"; maxUnits below 1 is treated as 1.
+func TimeAgoPrecise(timestamp, reference int64, maxUnits int) string {
+	diff := reference - timestamp
+	future := diff < 0
+	if diff < 0 {
+		diff = -diff
+	}
+	if diff == 0 {
+		return "just now"
+	}
+
+	var strs []string
+	for _, p := range decomposeCarry(diff, durationUnits, maxUnits, Nearest) {
+		strs = append(strs, p.verbose())
+	}
+	amount := strings.Join(strs, " ")
+	if future {
+		return "in " + amount
+	}
+	return amount + " ago"
+}
+
+// TimeAgoAuto is TimeAgo in the single most significant unit, rounding the
+// rest away per round: 89 minutes ago reads "1 hour ago" under Floor and
+// Nearest and "2 hours ago" under Ceil. Only identical timestamps read as
+// "just now".
+func TimeAgoAuto(timestamp, reference int64, round RoundMode) string {
+	diff := reference - timestamp
+	future := diff < 0
+	if diff < 0 {
+		diff = -diff
+	}
+	if diff == 0 {
+		return "just now"
+	}
+
+	amount := decomposeCarry(diff, durationUnits, 1, round)[0].verbose()
+	if future {
+		return "in " + amount
+	}
+	return amount + " ago"
+}
 
-	if compact {
-		return strings.Join(strs, " ")
+// Countdown describes the time left until target, e.g. "3 days, 4 hours
+// remaining", using up to maxUnits units. Once now reaches target it
+// returns "ended". Unlike Duration, a rounded unit carries, so 7199 seconds
+// to two units is "2 hours remaining"; maxUnits below 1 is treated as 1.
+func Countdown(target, now int64, maxUnits int) string {
+	if now >= target {
+		return "ended"
 	}
-	return strings.Join(strs, ", ")
+	return formatParts(decomposeCarry(target-now, durationUnits, maxUnits, Nearest), DurationOptions{}) + " remaining"
 }
 
 var (
-	errEmpty       = errors.New("empty duration string")
-	errUnrecognized = errors.New("unrecognized duration format")
-	errNegative    = errors.New("negative duration")
-	errBareNumber  = errors.New("bare number without units")
-	errUnknownUnit = errors.New("unknown unit")
+	errEmpty         = errors.New("empty duration string")
+	errUnrecognized  = errors.New("unrecognized duration format")
+	errNegative      = errors.New("negative duration")
+	errBareNumber    = errors.New("bare number without units")
+	errUnknownUnit   = errors.New("unknown unit")
+	errDuplicateUnit = errors.New("duplicate unit")
+	errUnitOrder     = errors.New("units out of order")
+	errTimestamp     = errors.New("unrecognized timestamp format")
 )
 
 // unitSeconds maps unit aliases (lowercase) to their value in seconds.
 var unitSeconds = map[string]float64{
+	"decade": 315360000, "decades": 315360000,
 	"y": 31536000, "yr": 31536000, "yrs": 31536000, "year": 31536000, "years": 31536000,
 	"mo": 2592000, "month": 2592000, "months": 2592000,
+	"fortnight": 1209600, "fortnights": 1209600,
 	"w": 604800, "wk": 604800, "wks": 604800, "week": 604800, "weeks": 604800,
 	"d": 86400, "day": 86400, "days": 86400,
 	"h": 3600, "hr": 3600, "hrs": 3600, "hour": 3600, "hours": 3600,
 	"m": 60, "min": 60, "mins": 60, "minute": 60, "minutes": 60,
 	"s": 1, "sec": 1, "secs": 1, "second": 1, "seconds": 1,
+	"ms": 1e-3, "msec": 1e-3, "msecs": 1e-3, "millisecond": 1e-3, "milliseconds": 1e-3,
+	"us": 1e-6, "usec": 1e-6, "usecs": 1e-6, "microsecond": 1e-6, "microseconds": 1e-6,
+	"ns": 1e-9, "nsec": 1e-9, "nsecs": 1e-9, "nanosecond": 1e-9, "nanoseconds": 1e-9,
 }
 
-// Regex for matching number+unit pairs
-var pairRegex = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*([a-zA-Z]+)`)
+// Regex for matching number+unit pairs; numbers may be decimals, including
+// a bare leading point (".5h")
+var pairRegex = regexp.MustCompile(`(\d+(?:\.\d+)?|\.\d+)\s*([a-zA-Z]+)`)
 
-// Regex for colon format
-var colonRegex = regexp.MustCompile(`^(\d+):(\d{2})(?::(\d{2}))?$`)
+// Regex for strict input: pairs separated only by whitespace, a comma, or "and"
+var strictPairsRegex = regexp.MustCompile(`(?i)^(?:\d+(?:\.\d+)?|\.\d+)\s*[a-z]+(?:(?:\s*,\s*(?:and\s+)?|\s+and\s+|\s*)(?:\d+(?:\.\d+)?|\.\d+)\s*[a-z]+)*$`)
 
-// ParseDuration parses a human-written duration string into total seconds.
-func ParseDuration(input string) (int, error) {
-	s := strings.TrimSpace(input)
-	if s == "" {
-		return 0, errEmpty
-	}
+// Regex for colon format; seconds may carry a fraction ("1:30:00.5")
+var colonRegex = regexp.MustCompile(`^(\d+):(\d{1,2})(?::(\d{1,2}(?:\.\d+)?))?$`)
 
-	// Check for negative
-	if strings.HasPrefix(s, "-") {
-		return 0, errNegative
+// numberWords maps spelled-out numbers to their values.
+var numberWords = map[string]int{
+	"zero": 0, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5,
+	"six": 6, "seven": 7, "eight": 8, "nine": 9, "ten": 10,
+	"eleven": 11, "twelve": 12, "thirteen": 13, "fourteen": 14, "fifteen": 15,
+	"sixteen": 16, "seventeen": 17, "eighteen": 18, "nineteen": 19, "twenty": 20,
+	"thirty": 30, "forty": 40, "fifty": 50, "sixty": 60,
+	"seventy": 70, "eighty": 80, "ninety": 90, "hundred": 100,
+}
+
+// numberWordsRegex matches a run of number words such as "twenty-five" or
+// "one hundred twenty".
+var numberWordsRegex = func() *regexp.Regexp {
+	words := make([]string, 0, len(numberWords))
+	for w := range numberWords {
+		words = append(words, w)
 	}
+	// Longest first so "seventeen" wins over "seven"
+	sort.Slice(words, func(i, j int) bool { return len(words[i]) > len(words[j]) })
+	alt := strings.Join(words, "|")
+	return regexp.MustCompile(`(?i)\b(?:` + alt + `)(?:[\s-]+(?:` + alt + `))*\b`)
+}()
 
-	// Try colon format first
-	if m := colonRegex.FindStringSubmatch(s); m != nil {
-		hours, _ := strconv.Atoi(m[1])
-		minutes, _ := strconv.Atoi(m[2])
-		total := hours*3600 + minutes*60
-		if m[3] != "" {
-			secs, _ := strconv.Atoi(m[3])
-			total += secs
+// spellNumbers rewrites runs of number words as digits: "two hours thirty
+// minutes" becomes "2 hours 30 minutes".
+func spellNumbers(s string) string {
+	return numberWordsRegex.ReplaceAllStringFunc(s, func(m string) string {
+		n := 0
+		for _, w := range strings.FieldsFunc(strings.ToLower(m), func(r rune) bool { return r == '-' || r == ' ' || r == '\t' }) {
+			if w == "hundred" {
+				n = max(n, 1) * 100
+			} else {
+				n += numberWords[w]
+			}
 		}
-		return total, nil
-	}
+		return strconv.Itoa(n)
+	})
+}
 
-	// Strip "and", commas for normalization
-	normalized := strings.ReplaceAll(s, ",", " ")
-	normalized = strings.ReplaceAll(normalized, " and ", " ")
+// Regexes for spelled-out quantities, applied in this order by expandWords
+var (
+	andAFractionRegex = regexp.MustCompile(`(?i)\b(an?|\d+(?:\.\d+)?)\s*([a-z]+)\s+and\s+a\s+(half|quarter)\b`)
+	halfRegex         = regexp.MustCompile(`(?i)\b(?:an?\s+)?half\s+(?:of\s+)?(?:an?\s+)?`)
+	quarterRegex      = regexp.MustCompile(`(?i)\b(?:an?\s+)?quarter\s+(?:of\s+)?(?:an?\s+)?`)
+	articleRegex      = regexp.MustCompile(`(?i)\ban?\s+`)
+)
 
-	// Find all number+unit pairs
-	matches := pairRegex.FindAllStringSubmatch(normalized, -1)
-	if len(matches) == 0 {
-		// Check if it's just a bare number
-		trimmed := strings.TrimSpace(normalized)
-		if _, err := strconv.ParseFloat(trimmed, 64); err == nil {
-			return 0, errBareNumber
+// expandWords rewrites fuzzy quantities as numbers so the pair matcher can
+// read them: "half an hour" becomes "0.5 hour", "an hour and a half" becomes
+// "1.5 hour", "a day and a quarter" becomes "1.25 day", and a leading
+// "a"/"an" becomes 1.
+func expandWords(s string) string {
+	s = andAFractionRegex.ReplaceAllStringFunc(s, func(m string) string {
+		sub := andAFractionRegex.FindStringSubmatch(m)
+		n := 1.0
+		if !strings.EqualFold(sub[1], "a") && !strings.EqualFold(sub[1], "an") {
+			n, _ = strconv.ParseFloat(sub[1], 64)
 		}
-		return 0, errUnrecognized
-	}
+		if strings.EqualFold(sub[3], "half") {
+			n += 0.5
+		} else {
+			n += 0.25
+		}
+		return strconv.FormatFloat(n, 'f', -1, 64) + " " + sub[2]
+	})
+	s = halfRegex.ReplaceAllString(s, "0.5 ")
+	s = quarterRegex.ReplaceAllString(s, "0.25 ")
+	return articleRegex.ReplaceAllString(s, "1 ")
+}
 
-	// Verify that the entire input is consumed by the pairs (plus allowed separators)
-	// Build a version of normalized with all pairs removed
-	remainder := normalized
-	for _, m := range matches {
-		remainder = strings.Replace(remainder, m[0], "", 1)
+// Regex for a trailing bare number after a unit, as in "1h30"
+var trailingNumberRegex = regexp.MustCompile(`(?i)(\d\s*([a-z]+))\s*(\d+(?:\.\d+)?)$`)
+
+// nextSmallerUnit maps a unit's length in seconds to the unit a trailing
+// bare number after it is read in.
+var nextSmallerUnit = map[float64]string{
+	315360000: "y",
+	1209600:   "d",
+	31536000:  "mo",
+	2592000:   "d",
+	604800:    "d",
+	86400:     "h",
+	3600:      "m",
+	60:        "s",
+}
+
+// impliedUnit gives a trailing bare number the next-smaller unit of the unit
+// before it: "1h30" becomes "1h30m" and "2m15" becomes "2m15s".
+func impliedUnit(s string) string {
+	m := trailingNumberRegex.FindStringSubmatchIndex(s)
+	if m == nil {
+		return s
 	}
-	remainder = strings.TrimSpace(remainder)
-	// Allow only whitespace, commas, "and" in remainder
-	remainder = strings.ReplaceAll(remainder, "and", "")
-	remainder = strings.ReplaceAll(remainder, ",", "")
-	remainder = strings.TrimSpace(remainder)
-	if remainder != "" {
-		return 0, errUnrecognized
+	secs, ok := unitSeconds[strings.ToLower(s[m[4]:m[5]])]
+	if !ok {
+		return s
 	}
+	next, ok := nextSmallerUnit[secs]
+	if !ok {
+		return s
+	}
+	return s + next
+}
 
-	total := 0.0
-	for _, m := range matches {
-		numStr := m[1]
-		unitStr := strings.ToLower(m[2])
+// expandDuration rewrites spelled-out and fuzzy quantities and implied
+// trailing units so that s reads as number+unit pairs.
+func expandDuration(s string) string {
+	return impliedUnit(expandWords(spellNumbers(s)))
+}
 
-		num, err := strconv.ParseFloat(numStr, 64)
+// Regex for ISO 8601 durations: PnYnMnWnDTnHnMnS, every component optional.
+var isoRegex = regexp.MustCompile(`^P(?:(\d+(?:\.\d+)?)Y)?(?:(\d+(?:\.\d+)?)M)?(?:(\d+(?:\.\d+)?)W)?(?:(\d+(?:\.\d+)?)D)?(?:T(?:(\d+(?:\.\d+)?)H)?(?:(\d+(?:\.\d+)?)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+// isoUnits gives the unitSeconds key for each isoRegex capture group.
+var isoUnits = []string{"y", "mo", "w", "d", "h", "m", "s"}
+
+// parseISO8601 parses an ISO 8601 duration such as "P1DT6H" into seconds.
+func parseISO8601(s string, opts ParseOptions) (float64, error) {
+	m := isoRegex.FindStringSubmatch(s)
+	if m == nil || s == "P" || strings.HasSuffix(s, "T") {
+		return 0, errUnrecognized
+	}
+
+	total, months := 0.0, 0.0
+	for i, unit := range isoUnits {
+		if m[i+1] == "" {
+			continue
+		}
+		num, err := strconv.ParseFloat(m[i+1], 64)
 		if err != nil {
 			return 0, errUnrecognized
 		}
-
-		secs, ok := unitSeconds[unitStr]
-		if !ok {
-			return 0, errUnknownUnit
+		if per, ok := opts.calendarMonths(unitSeconds[unit]); ok {
+			months += num * per
+		} else {
+			total += num * unitSeconds[unit]
 		}
-
-		total += num * secs
 	}
+	return total + opts.monthSeconds(months), nil
+}
 
+// ParseDuration parses a human-written duration string into total seconds.
+// ISO 8601 durations ("PT2H30M", "P1W") are accepted as well. Fractional
+// totals, including sub-second units, are rounded half away from zero.
+func ParseDuration(input string) (int, error) {
+	total, err := parseSeconds(input)
+	if err != nil {
+		return 0, err
+	}
 	return int(math.Round(total)), nil
 }
 
-// HumanDate returns a contextual date string based on proximity.
-func HumanDate(timestamp, reference int64) string {
-	ts := time.Unix(timestamp, 0).UTC()
-	ref := time.Unix(reference, 0).UTC()
+// ParseGoDuration is ParseDuration returning a time.Duration. Fractional
+// seconds are kept rather than rounded away.
+func ParseGoDuration(input string) (time.Duration, error) {
+	total, err := parseSeconds(input)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(math.Round(total * float64(time.Second))), nil
+}
 
-	// Truncate to dates
-	tsDate := time.Date(ts.Year(), ts.Month(), ts.Day(), 0, 0, 0, 0, time.UTC)
-	refDate := time.Date(ref.Year(), ref.Month(), ref.Day(), 0, 0, 0, 0, time.UTC)
+// ParseDurationStrict is ParseDuration that also rejects stray text between
+// or after the number+unit pairs, repeated units ("2h 3h"), and units out of
+// largest-to-smallest order ("30m 2h"). Colon and ISO 8601 forms are
+// accepted as in ParseDuration.
+func ParseDurationStrict(input string) (int, error) {
+	return ParseDurationWith(input, ParseOptions{Strict: true})
+}
 
-	dayDiff := int(tsDate.Sub(refDate).Hours() / 24)
+// MonthMode selects how ParseDurationWith converts months to seconds.
+type MonthMode int
 
-	switch {
+const (
+	Month30       MonthMode = iota // 30 days (the default)
+	MonthAverage                   // 30.436875 days, the Gregorian average
+	MonthCalendar                  // Calendar months counted from ParseOptions.Reference
+)
+
+// averageMonthSeconds is 365.2425 days divided by 12.
+const averageMonthSeconds = 2629746
+
+// ParseOptions configures ParseDurationWith.
+type ParseOptions struct {
+	Strict bool // Apply ParseDurationStrict's checks
+
+	// Months selects the month length. Under MonthAverage and MonthCalendar
+	// a year counts as 12 months (and a decade as 120), so "1y" is 365.2425
+	// days or one calendar year respectively.
+	Months MonthMode
+
+	// Reference is the Unix timestamp calendar months are counted from when
+	// Months is MonthCalendar. The day of the month is clamped to the target
+	// month's length, and fractional months beyond the whole ones are counted
+	// as 30 days.
+	Reference int64
+}
+
+// ParseDurationWith is ParseDuration with parsing options applied.
+func ParseDurationWith(input string, opts ParseOptions) (int, error) {
+	total, err := parseDuration(input, opts)
+	if err != nil {
+		return 0, err
+	}
+	return int(math.Round(total)), nil
+}
+
+// ParseDurationFrom is ParseDuration with months and years counted as
+// calendar months from ref. A month that lands past the end of a shorter
+// month stops on its last day, so "1 month" from January 31 ends on the last
+// day of February.
+func ParseDurationFrom(input string, ref int64) (int, error) {
+	return ParseDurationWith(input, ParseOptions{Months: MonthCalendar, Reference: ref})
+}
+
+// ParseDurationDetailed is ParseDuration that, on failure, also reports what
+// it understood: seconds totals the parts of the input it could read and
+// unparsed holds the rest, so "2 hours and banana" yields 7200, "banana",
+// and an error. Each unparsed fragment is copied verbatim from input, and
+// separate fragments are joined by a space. On success unparsed is empty.
+func ParseDurationDetailed(input string) (seconds int, unparsed string, err error) {
+	total, err := parseSeconds(input)
+	if err == nil {
+		return int(math.Round(total)), "", nil
+	}
+
+	// Empty, negative, and ISO 8601 input is rejected as a whole.
+	s := strings.TrimSpace(input)
+	if err == errEmpty || err == errNegative || strings.HasPrefix(s, "P") {
+		return 0, s, err
+	}
+
+	// From each word, take the longest run of words that parses on its
+	// own; words no run covers are unparsed. Working on spans of input
+	// rather than on its expanded form keeps fragments verbatim.
+	words := wordRegex.FindAllStringIndex(input, -1)
+	var fragments [][2]int
+	total = 0
+	lastUnparsed := -2
+	for i := 0; i < len(words); {
+		j := len(words)
+		for ; j > i; j-- {
+			if secs, err := parseSeconds(input[words[i][0]:words[j-1][1]]); err == nil {
+				total += secs
+				break
+			}
+		}
+		if j > i {
+			i = j
+			continue
+		}
+
+		// Connecting "and"s are not worth reporting.
+		w := words[i]
+		if !strings.EqualFold(strings.TrimRight(input[w[0]:w[1]], ","), "and") {
+			if lastUnparsed == i-1 {
+				fragments[len(fragments)-1][1] = w[1]
+			} else {
+				fragments = append(fragments, [2]int{w[0], w[1]})
+			}
+			lastUnparsed = i
+		}
+		i++
+	}
+
+	rest := make([]string, len(fragments))
+	for i, f := range fragments {
+		rest[i] = input[f[0]:f[1]]
+	}
+	return int(math.Round(total)), strings.Join(rest, " "), err
+}
+
+// wordRegex splits input into whitespace-separated words for
+// ParseDurationDetailed.
+var wordRegex = regexp.MustCompile(`\S+`)
+
+// calendarMonths reports how many months a unit of secs spans when opts
+// counts months apart from fixed-length units.
+func (opts ParseOptions) calendarMonths(secs float64) (float64, bool) {
+	if opts.Months == Month30 {
+		return 0, false
+	}
+	switch secs {
+	case unitSeconds["mo"]:
+		return 1, true
+	case unitSeconds["y"]:
+		return 12, true
+	case unitSeconds["decade"]:
+		return 120, true
+	}
+	return 0, false
+}
+
+// monthSeconds converts a month count to seconds under opts.
+func (opts ParseOptions) monthSeconds(months float64) float64 {
+	switch opts.Months {
+	case MonthAverage:
+		return months * averageMonthSeconds
+	case MonthCalendar:
+		whole := math.Trunc(months)
+		ref := time.Unix(opts.Reference, 0).UTC()
+		return float64(addMonths(ref, int(whole)).Unix()-opts.Reference) + (months-whole)*unitSeconds["mo"]
+	default:
+		return months * unitSeconds["mo"]
+	}
+}
+
+// addMonths moves t by n calendar months, clamping the day to the last day
+// of the target month where time.AddDate would roll over into the next one.
+func addMonths(t time.Time, n int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(n), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(t.Day(), lastDay)-1)
+}
+
+// parseSeconds holds the parsing logic shared by ParseDuration and
+// ParseGoDuration, returning unrounded seconds.
+func parseSeconds(input string) (float64, error) {
+	return parseDuration(input, ParseOptions{})
+}
+
+// parseDuration implements parseSeconds under opts.
+func parseDuration(input string, opts ParseOptions) (float64, error) {
+	s := strings.TrimSpace(input)
+	if s == "" {
+		return 0, errEmpty
+	}
+
+	// Check for negative
+	if strings.HasPrefix(s, "-") {
+		return 0, errNegative
+	}
+
+	// ISO 8601 durations start with P and cannot be mixed with other forms
+	if strings.HasPrefix(s, "P") {
+		return parseISO8601(s, opts)
+	}
+
+	// Try colon format first
+	if m := colonRegex.FindStringSubmatch(s); m != nil {
+		hours, _ := strconv.Atoi(m[1])
+		minutes, _ := strconv.Atoi(m[2])
+		total := float64(hours*3600 + minutes*60)
+		if m[3] != "" {
+			secs, _ := strconv.ParseFloat(m[3], 64)
+			total += secs
+		}
+		return total, nil
+	}
+
+	// Spell out fuzzy quantities, then strip "and", commas for normalization
+	expanded := expandDuration(s)
+	normalized := strings.ReplaceAll(expanded, ",", " ")
+	normalized = strings.ReplaceAll(normalized, " and ", " ")
+
+	// Find all number+unit pairs
+	matches := pairRegex.FindAllStringSubmatch(normalized, -1)
+	if len(matches) == 0 {
+		// Check if it's just a bare number
+		trimmed := strings.TrimSpace(normalized)
+		if _, err := strconv.ParseFloat(trimmed, 64); err == nil {
+			return 0, errBareNumber
+		}
+		return 0, errUnrecognized
+	}
+
+	// Verify that the entire input is consumed by the pairs (plus allowed separators)
+	// Build a version of normalized with all pairs removed
+	remainder := normalized
+	for _, m := range matches {
+		remainder = strings.Replace(remainder, m[0], "", 1)
+	}
+	remainder = strings.TrimSpace(remainder)
+	// Allow only whitespace, commas, "and" in remainder
+	remainder = strings.ReplaceAll(remainder, "and", "")
+	remainder = strings.ReplaceAll(remainder, ",", "")
+	remainder = strings.TrimSpace(remainder)
+	if remainder != "" {
+		return 0, errUnrecognized
+	}
+	if opts.Strict && !strictPairsRegex.MatchString(expanded) {
+		return 0, errUnrecognized
+	}
+
+	total, months := 0.0, 0.0
+	prevSecs := math.Inf(1)
+	for _, m := range matches {
+		numStr := m[1]
+		unitStr := strings.ToLower(m[2])
+
+		num, err := strconv.ParseFloat(numStr, 64)
+		if err != nil {
+			return 0, errUnrecognized
+		}
+
+		secs, ok := unitSeconds[unitStr]
+		if !ok {
+			return 0, errUnknownUnit
+		}
+		if opts.Strict {
+			switch {
+			case secs == prevSecs:
+				return 0, errDuplicateUnit
+			case secs > prevSecs:
+				return 0, errUnitOrder
+			}
+			prevSecs = secs
+		}
+
+		if per, ok := opts.calendarMonths(secs); ok {
+			months += num * per
+		} else {
+			total += num * secs
+		}
+	}
+
+	return total + opts.monthSeconds(months), nil
+}
+
+// NormalizeDuration parses input with ParseDuration and re-emits it in
+// canonical compact form with every nonzero unit, so "90 minutes", "1.5
+// hours", and "1:30" all become "1h 30m".
+func NormalizeDuration(input string) (string, error) {
+	seconds, err := ParseDuration(input)
+	if err != nil {
+		return "", err
+	}
+	return DurationWith(seconds, DurationOptions{Compact: true, AllUnits: true}), nil
+}
+
+// ParseTimestamp parses an RFC 3339 timestamp ("2024-03-05T14:30:00Z") or a
+// bare date ("2024-03-05", read as UTC midnight) into Unix seconds.
+func ParseTimestamp(input string) (int64, error) {
+	s := strings.TrimSpace(input)
+	for _, layout := range []string{time.RFC3339Nano, time.DateOnly} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.Unix(), nil
+		}
+	}
+	return 0, errTimestamp
+}
+
+// HumanDate returns a contextual date string based on proximity.
+func HumanDate(timestamp, reference int64) string {
+	return HumanDateIn(timestamp, reference, time.UTC)
+}
+
+// HumanDateIn is HumanDate with day boundaries computed in loc, so "Today"
+// and "Yesterday" follow the caller's local midnight rather than UTC's.
+func HumanDateIn(timestamp, reference int64, loc *time.Location) string {
+	return humanDate(time.Unix(timestamp, 0).In(loc), time.Unix(reference, 0).In(loc), DateOptions{})
+}
+
+// HumanDateT is HumanDate for time.Time values. Each value's date is read in
+// its own location, so pass both in the zone the reader lives in.
+func HumanDateT(t, now time.Time) string {
+	return humanDate(t, now, DateOptions{})
+}
+
+// HumanDateWith is HumanDate with formatting options applied.
+func HumanDateWith(timestamp, reference int64, opts DateOptions) string {
+	return opts.Case.apply(humanDate(time.Unix(timestamp, 0).UTC(), time.Unix(reference, 0).UTC(), opts), opts.names())
+}
+
+// humanDate implements HumanDate for times already in the display location.
+func humanDate(ts, ref time.Time, opts DateOptions) string {
+	dayDiff := calendarDays(ts, ref)
+	words := opts.words()
+
+	switch {
+	case dayDiff == 0 && opts.EarlierLater:
+		// Within TimeAgo's "just now" window the day is simply "Today".
+		switch diff := ts.Sub(ref); {
+		case diff < -44*time.Second:
+			return words.EarlierToday
+		case diff > 44*time.Second:
+			return words.LaterToday
+		}
+		return words.Today
 	case dayDiff == 0:
-		return "Today"
+		return words.Today
 	case dayDiff == -1:
-		return "Yesterday"
+		return words.Yesterday
 	case dayDiff == 1:
-		return "Tomorrow"
+		return words.Tomorrow
+	case opts.CalendarWeeks && dayDiff >= -6 && dayDiff <= 6:
+		switch weekDiff := opts.weekOffset(ts, ref); {
+		case weekDiff < 0:
+			return opts.relativeDay(words.Last, ts.Weekday())
+		case weekDiff > 0:
+			return opts.relativeDay(words.Next, ts.Weekday())
+		}
+		return opts.relativeDay(words.This, ts.Weekday())
 	case dayDiff >= -6 && dayDiff <= -2:
-		return "Last " + ts.Weekday().String()
+		return opts.relativeDay(words.Last, ts.Weekday())
 	case dayDiff >= 2 && dayDiff <= 6:
-		return "This " + ts.Weekday().String()
+		return opts.relativeDay(words.This, ts.Weekday())
+	case opts.RelativeFuture && dayDiff > 6:
+		return relativeFuture(dayDiff)
 	case ts.Year() == ref.Year():
-		return fmt.Sprintf("%s %d", ts.Month().String(), ts.Day())
+		return opts.monthDay(opts.month(ts.Month()), opts.day(ts.Day()))
 	default:
-		return fmt.Sprintf("%s %d, %d", ts.Month().String(), ts.Day(), ts.Year())
+		return opts.monthDay(opts.month(ts.Month()), opts.day(ts.Day())) + opts.yearSuffix(ts.Year())
+	}
+}
+
+// relativeFuture phrases a date more than six days ahead in weeks, months,
+// or years, using the same tier boundaries as TimeAgo where they overlap.
+func relativeFuture(days int) string {
+	plural := func(n int, unit string) string {
+		if n == 1 {
+			return fmt.Sprintf("In 1 %s", unit)
+		}
+		return fmt.Sprintf("In %d %ss", n, unit)
+	}
+
+	switch {
+	case days <= 27:
+		return plural(int(math.Round(float64(days)/7)), "week")
+	case days <= 45:
+		return "Next month"
+	case days <= 319:
+		return plural(int(math.Round(float64(days)/30)), "month")
+	case days <= 547:
+		return "Next year"
+	default:
+		return plural(int(math.Round(float64(days)/365)), "year")
+	}
+}
+
+// WeekendDays is the set of weekdays treated as the weekend, indexed by
+// time.Weekday.
+type WeekendDays [7]bool
+
+// Common weekend conventions.
+var (
+	SaturdaySunday = WeekendDays{time.Saturday: true, time.Sunday: true}
+	FridaySaturday = WeekendDays{time.Friday: true, time.Saturday: true}
+)
+
+// Includes reports whether the date of timestamp in loc falls on a weekend
+// day of w.
+func (w WeekendDays) Includes(timestamp int64, loc *time.Location) bool {
+	return w[time.Unix(timestamp, 0).In(loc).Weekday()]
+}
+
+// IsWeekend reports whether timestamp falls on a Saturday or Sunday in loc.
+func IsWeekend(timestamp int64, loc *time.Location) bool {
+	return SaturdaySunday.Includes(timestamp, loc)
+}
+
+// IsToday reports whether timestamp falls on now's calendar date in loc,
+// using the same day boundaries as HumanDateIn's "Today".
+func IsToday(timestamp, now int64, loc *time.Location) bool {
+	return daysFrom(timestamp, now, loc) == 0
+}
+
+// IsYesterday reports whether timestamp falls on the calendar date before
+// now's in loc.
+func IsYesterday(timestamp, now int64, loc *time.Location) bool {
+	return daysFrom(timestamp, now, loc) == -1
+}
+
+// IsTomorrow reports whether timestamp falls on the calendar date after
+// now's in loc.
+func IsTomorrow(timestamp, now int64, loc *time.Location) bool {
+	return daysFrom(timestamp, now, loc) == 1
+}
+
+// daysFrom returns calendarDays for two Unix timestamps read in loc.
+func daysFrom(timestamp, now int64, loc *time.Location) int {
+	return calendarDays(time.Unix(timestamp, 0).In(loc), time.Unix(now, 0).In(loc))
+}
+
+// BusinessDaysBetween counts the weekdays (Monday through Friday) among the
+// UTC calendar dates in [start, end). Reversed arguments are swapped.
+func BusinessDaysBetween(start, end int64) int {
+	return BusinessDaysBetweenIn(start, end, time.UTC)
+}
+
+// BusinessDaysBetweenIn is BusinessDaysBetween with dates read in loc.
+func BusinessDaysBetweenIn(start, end int64, loc *time.Location) int {
+	if start > end {
+		start, end = end, start
+	}
+
+	s := time.Unix(start, 0).In(loc)
+	days := calendarDays(time.Unix(end, 0).In(loc), s)
+
+	// Whole weeks contribute five business days each; walk the rest.
+	count := days / 7 * 5
+	wd := s.Weekday()
+	for i := 0; i < days%7; i++ {
+		if !SaturdaySunday[wd] {
+			count++
+		}
+		wd = (wd + 1) % 7
+	}
+	return count
+}
+
+// AgeYears returns the number of completed years from birth to now, reading
+// both dates in UTC. Someone born on February 29 turns a year older on
+// March 1 in common years. Returns 0 if now precedes birth.
+func AgeYears(birth, now int64) int {
+	return AgeYearsIn(birth, now, time.UTC)
+}
+
+// AgeYearsIn is AgeYears with dates read in loc.
+func AgeYearsIn(birth, now int64, loc *time.Location) int {
+	b := time.Unix(birth, 0).In(loc)
+	n := time.Unix(now, 0).In(loc)
+
+	age := n.Year() - b.Year()
+	if n.Month() < b.Month() || (n.Month() == b.Month() && n.Day() < b.Day()) {
+		age--
+	}
+	return max(age, 0)
+}
+
+// NextWeekday returns the Unix timestamp of midnight in loc on the next
+// target weekday after from's date. If from already falls on target, the
+// result is a week later.
+func NextWeekday(from int64, target time.Weekday, loc *time.Location) int64 {
+	t := time.Unix(from, 0).In(loc)
+	days := int(target-t.Weekday()+7) % 7
+	if days == 0 {
+		days = 7
+	}
+	return time.Date(t.Year(), t.Month(), t.Day()+days, 0, 0, 0, 0, loc).Unix()
+}
+
+// FloorTime rounds timestamp down to a multiple of unit seconds, counted
+// from the Unix epoch, so a unit of 86400 gives UTC midnight. A unit below
+// 1 returns timestamp unchanged, as do CeilTime and RoundTime.
+func FloorTime(timestamp int64, unit int) int64 {
+	if unit < 1 {
+		return timestamp
+	}
+	u := int64(unit)
+	r := timestamp % u
+	if r < 0 {
+		r += u
+	}
+	return timestamp - r
+}
+
+// CeilTime rounds timestamp up to a multiple of unit seconds.
+func CeilTime(timestamp int64, unit int) int64 {
+	floor := FloorTime(timestamp, unit)
+	if floor == timestamp {
+		return floor
+	}
+	return floor + int64(unit)
+}
+
+// RoundTime rounds timestamp to the nearest multiple of unit seconds,
+// rounding halves up.
+func RoundTime(timestamp int64, unit int) int64 {
+	if unit < 1 {
+		return timestamp
+	}
+	return FloorTime(timestamp+int64(unit)/2, unit)
+}
+
+// WeekOfYear returns the ISO 8601 year and week number of the UTC date.
+// Around January 1 the ISO year can differ from the calendar year.
+func WeekOfYear(timestamp int64) (year, week int) {
+	return time.Unix(timestamp, 0).UTC().ISOWeek()
+}
+
+// FormatWeek renders the ISO 8601 week of the UTC date, e.g. "2024-W10".
+func FormatWeek(timestamp int64) string {
+	year, week := WeekOfYear(timestamp)
+	return fmt.Sprintf("%04d-W%02d", year, week)
+}
+
+// DateOptions configures the date formatters that take options.
+type DateOptions struct {
+	Clock24 bool       // Render times as "15:05" instead of "3:05 PM"
+	Ordinal bool       // Render days as "5th" instead of "5"
+	Names   *Names     // Month and weekday names (default: EnglishNames())
+	Words   *DateWords // Relative day phrases (default: English)
+
+	ShortWeekdays bool // "Last Sat" instead of "Last Saturday"
+
+	// RelativeFuture phrases dates more than a week ahead as "In 2 weeks",
+	// "Next month", "In 3 months", "Next year", or "In 2 years" instead of
+	// a calendar date. These phrases are always English.
+	RelativeFuture bool
+
+	// RangeSeparator joins the ends of a range (default "\u2013", an en
+	// dash). Spaces are added around it as each range form requires, so
+	// pass "-" or "to" rather than " - " or " to ".
+	RangeSeparator string
+
+	// CalendarWeeks bases "Last"/"This"/"Next <weekday>" on calendar weeks
+	// beginning on WeekStart instead of on the day difference alone: a day
+	// in the reference's week is "This", in the week before "Last", and in
+	// the week after "Next".
+	CalendarWeeks bool
+
+	// WeekStart is the first day of the week (default Sunday). It is read
+	// only when CalendarWeeks is set; on its own it changes nothing.
+	WeekStart time.Weekday
+
+	// EarlierLater splits "Today" into "Earlier today" and "Later today"
+	// once the time is 45 seconds or more from the reference; up to 44
+	// seconds either side it stays "Today", as TimeAgo says "just now".
+	EarlierLater bool
+
+	// Compact abbreviates month names ("Mar 5, 1999 – Jan 15, 2024").
+	Compact bool
+
+	Case Case // Letter case of the date phrase (default: capitalized, "Today")
+
+	// HideCurrentYear drops the year from a range that lies entirely in
+	// Reference's year, a Unix timestamp: "March 5 – April 10".
+	HideCurrentYear bool
+	Reference       int64
+
+	Order DateOrder // Component order of full dates (default MDY, "March 5, 2024")
+}
+
+// DateOrder is the order of the day, month, and year in a written date.
+type DateOrder int
+
+const (
+	MDY DateOrder = iota // "March 5, 2024"
+	DMY                  // "5 March 2024"
+)
+
+// monthDay joins rendered month and day names in opts.Order: "March 5" or
+// "5 March".
+func (opts DateOptions) monthDay(month, day string) string {
+	if opts.Order == DMY {
+		return day + " " + month
+	}
+	return month + " " + day
+}
+
+// yearSuffix renders the year that follows a monthDay: ", 2024" for MDY and
+// " 2024" for DMY.
+func (opts DateOptions) yearSuffix(year int) string {
+	if opts.Order == DMY {
+		return " " + formatYear(year)
+	}
+	return ", " + formatYear(year)
+}
+
+// names returns the name table in effect for opts.
+func (opts DateOptions) names() *Names {
+	if opts.Names == nil {
+		return &englishNames
+	}
+	return opts.Names
+}
+
+// words returns the relative day phrases in effect for opts.
+func (opts DateOptions) words() *DateWords {
+	if opts.Words == nil {
+		return &englishWords
+	}
+	return opts.Words
+}
+
+// relativeDay fills a weekday into one of the Last/This/Next formats and
+// capitalizes the result, so "%s dernier" reads "Samedi dernier".
+func (opts DateOptions) relativeDay(format string, wd time.Weekday) string {
+	s := fmt.Sprintf(format, opts.weekday(wd))
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+// weekday renders a weekday name according to opts, falling back to the
+// full name when the table has no abbreviation.
+func (opts DateOptions) weekday(wd time.Weekday) string {
+	names := opts.names()
+	if opts.ShortWeekdays && names.ShortWeekdays[wd] != "" {
+		return names.ShortWeekdays[wd]
+	}
+	return names.Weekdays[wd]
+}
+
+// weekOffset returns how many calendar weeks, starting on opts.WeekStart,
+// separate t's week from ref's week.
+func (opts DateOptions) weekOffset(t, ref time.Time) int {
+	tStart := calendarDays(t, ref) - int((t.Weekday()-opts.WeekStart+7)%7)
+	refStart := -int((ref.Weekday() - opts.WeekStart + 7) % 7)
+	return (tStart - refStart) / 7
+}
+
+// rangeSeparator returns the mark joining range ends under opts.
+func (opts DateOptions) rangeSeparator() string {
+	if sep := strings.TrimSpace(opts.RangeSeparator); sep != "" {
+		return sep
+	}
+	return "\u2013"
+}
+
+// month renders a month name according to opts, falling back to the full
+// name when the table has no abbreviation.
+func (opts DateOptions) month(m time.Month) string {
+	names := opts.names()
+	if opts.Compact && names.ShortMonths[m-1] != "" {
+		return names.ShortMonths[m-1]
+	}
+	return names.Months[m-1]
+}
+
+// day renders a day of the month according to opts.
+func (opts DateOptions) day(n int) string {
+	if opts.Ordinal {
+		return Ordinal(n)
 	}
+	return strconv.Itoa(n)
+}
+
+// Ordinal returns n with its English ordinal suffix: 1st, 2nd, 3rd, 4th,
+// 11th, 12th, 13th, 21st, and so on.
+func Ordinal(n int) string {
+	abs := n
+	if abs < 0 {
+		abs = -abs
+	}
+
+	suffix := "th"
+	if abs%100 < 11 || abs%100 > 13 {
+		switch abs % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return strconv.Itoa(n) + suffix
+}
+
+// FormatClock renders just the wall-clock time of timestamp in loc, as
+// "3:05 PM" or, with clock24, "15:05".
+func FormatClock(timestamp int64, loc *time.Location, clock24 bool) string {
+	return formatClock(time.Unix(timestamp, 0).In(loc), clock24)
+}
+
+// formatClock renders the time of day in 12- or 24-hour style.
+func formatClock(t time.Time, clock24 bool) string {
+	if clock24 {
+		return t.Format("15:04")
+	}
+	return t.Format("3:04 PM")
+}
+
+// HumanDateTime is HumanDate with the time of day. Relative day words are
+// joined with "at" ("Tomorrow at 9:00 AM", "Last Friday at 09:00"); calendar
+// dates take a comma ("March 5, 2024, 3:05 PM").
+func HumanDateTime(timestamp, reference int64, opts DateOptions) string {
+	ts := time.Unix(timestamp, 0).UTC()
+	ref := time.Unix(reference, 0).UTC()
+
+	date := opts.Case.apply(humanDate(ts, ref, opts), opts.names())
+	clock := formatClock(ts, opts.Clock24)
+	if d := calendarDays(ts, ref); d >= -6 && d <= 6 || opts.RelativeFuture && d > 6 {
+		return date + " at " + clock
+	}
+	return date + ", " + clock
+}
+
+// formatYear renders a year, writing years before 1 as BCE. Go counts
+// astronomically, so year 0 is 1 BCE and year -43 is 44 BCE.
+func formatYear(year int) string {
+	if year < 1 {
+		return strconv.Itoa(1-year) + " BCE"
+	}
+	return strconv.Itoa(year)
+}
+
+// calendarDays returns the number of calendar days from ref's date to t's date,
+// reading both dates in their own locations. Comparing the dates as UTC
+// midnights keeps DST transitions from producing 23- or 25-hour days, and
+// subtracting Unix seconds rather than using time.Sub avoids its ~292-year
+// saturation for distant dates.
+func calendarDays(t, ref time.Time) int {
+	tDate := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	refDate := time.Date(ref.Year(), ref.Month(), ref.Day(), 0, 0, 0, 0, time.UTC)
+	return int((tDate.Unix() - refDate.Unix()) / 86400)
 }
 
 // DateRange formats two timestamps as a smart date range.
 func DateRange(start, end int64) string {
+	return DateRangeWith(start, end, DateOptions{})
+}
+
+// DateRangeWith is DateRange with formatting options applied.
+func DateRangeWith(start, end int64, opts DateOptions) string {
+	return dateRange(time.Unix(start, 0).UTC(), time.Unix(end, 0).UTC(), opts)
+}
+
+// DateRangeRelative is DateRange that reads "This week" or "Last week" when
+// both ends fall in the same ISO week (Monday through Sunday, UTC) as now or
+// the week before it.
+func DateRangeRelative(start, end, now int64) string {
+	sYear, sWeek := WeekOfYear(start)
+	eYear, eWeek := WeekOfYear(end)
+	if sYear == eYear && sWeek == eWeek {
+		if y, w := WeekOfYear(now); sYear == y && sWeek == w {
+			return "This week"
+		}
+		if y, w := WeekOfYear(now - 7*86400); sYear == y && sWeek == w {
+			return "Last week"
+		}
+	}
+	return DateRange(start, end)
+}
+
+// DateRangeIn is DateRange with the same-day, same-month, and same-year
+// decisions made in loc rather than UTC.
+func DateRangeIn(start, end int64, loc *time.Location) string {
+	return dateRange(time.Unix(start, 0).In(loc), time.Unix(end, 0).In(loc), DateOptions{})
+}
+
+// DateRangeT is DateRange for time.Time values. Each end's date is read in
+// its own location.
+func DateRangeT(start, end time.Time) string {
+	return dateRange(start, end, DateOptions{})
+}
+
+// dateRange implements DateRangeWith for times already in their display
+// locations.
+func dateRange(s, e time.Time, opts DateOptions) string {
+	if s.After(e) {
+		s, e = e, s
+	}
+
+	dash := opts.rangeSeparator()
+	sDay, eDay := opts.day(s.Day()), opts.day(e.Day())
+	sMonth, eMonth := opts.month(s.Month()), opts.month(e.Month())
+
+	// A single mark joins days tightly ("15–22"); words need spaces.
+	tight := dash
+	if utf8.RuneCountInString(dash) > 1 {
+		tight = " " + dash + " "
+	}
+
+	// The shared year of a same-year range, dropped when it is the
+	// reference's year and HideCurrentYear is set.
+	year := opts.yearSuffix(s.Year())
+	if opts.HideCurrentYear && s.Year() == time.Unix(opts.Reference, 0).In(s.Location()).Year() {
+		year = ""
+	}
+	sDate, eDate := opts.monthDay(sMonth, sDay), opts.monthDay(eMonth, eDay)
+
+	switch {
+	case s.Year() == e.Year() && s.Month() == e.Month() && s.Day() == e.Day():
+		// Same day
+		return sDate + year
+	case s.Year() == e.Year() && s.Month() == e.Month():
+		// Same month
+		if opts.Order == DMY {
+			return fmt.Sprintf("%s%s%s %s%s", sDay, tight, eDay, sMonth, year)
+		}
+		return fmt.Sprintf("%s %s%s%s%s", sMonth, sDay, tight, eDay, year)
+	case s.Year() == e.Year():
+		// Same year, different month
+		return fmt.Sprintf("%s %s %s%s", sDate, dash, eDate, year)
+	default:
+		// Different years
+		return fmt.Sprintf("%s%s %s %s%s", sDate, opts.yearSuffix(s.Year()), dash, eDate, opts.yearSuffix(e.Year()))
+	}
+}
+
+// DateTimeRange is DateRange with clock times on each end, e.g.
+// "March 5, 2024, 9:00 AM – 6:00 PM". The year is shared when both ends fall
+// in the same year.
+func DateTimeRange(start, end int64) string {
+	return DateTimeRangeWith(start, end, DateOptions{})
+}
+
+// DateTimeRangeWith is DateTimeRange with formatting options applied.
+func DateTimeRangeWith(start, end int64, opts DateOptions) string {
 	if start > end {
 		start, end = end, start
 	}
@@ -294,20 +1605,408 @@ func DateRange(start, end int64) string {
 	s := time.Unix(start, 0).UTC()
 	e := time.Unix(end, 0).UTC()
 
-	enDash := "\u2013"
+	dash := opts.rangeSeparator()
+	sDate := opts.monthDay(opts.month(s.Month()), opts.day(s.Day()))
+	eDate := opts.monthDay(opts.month(e.Month()), opts.day(e.Day()))
+	sYear, eYear := opts.yearSuffix(s.Year()), opts.yearSuffix(e.Year())
+	sClock := formatClock(s, opts.Clock24)
+	eClock := formatClock(e, opts.Clock24)
 
 	switch {
+	case start == end:
+		return fmt.Sprintf("%s%s, %s", sDate, sYear, sClock)
 	case s.Year() == e.Year() && s.Month() == e.Month() && s.Day() == e.Day():
 		// Same day
-		return fmt.Sprintf("%s %d, %d", s.Month().String(), s.Day(), s.Year())
-	case s.Year() == e.Year() && s.Month() == e.Month():
-		// Same month
-		return fmt.Sprintf("%s %d%s%d, %d", s.Month().String(), s.Day(), enDash, e.Day(), s.Year())
+		return fmt.Sprintf("%s%s, %s %s %s", sDate, sYear, sClock, dash, eClock)
 	case s.Year() == e.Year():
-		// Same year, different month
-		return fmt.Sprintf("%s %d %s %s %d, %d", s.Month().String(), s.Day(), enDash, e.Month().String(), e.Day(), s.Year())
+		// Same year, different day
+		return fmt.Sprintf("%s, %s %s %s%s, %s", sDate, sClock, dash, eDate, eYear, eClock)
 	default:
 		// Different years
-		return fmt.Sprintf("%s %d, %d %s %s %d, %d", s.Month().String(), s.Day(), s.Year(), enDash, e.Month().String(), e.Day(), e.Year())
+		return fmt.Sprintf("%s%s, %s %s %s%s, %s", sDate, sYear, sClock, dash, eDate, eYear, eClock)
+	}
+}
+
+// Locale holds the words a language needs for relative time and dates.
+type Locale struct {
+	JustNow string // Phrase for differences under 45 seconds
+	Past    string // Format for past differences; %s receives "<n> <unit>"
+	Future  string // Format for future differences; %s receives "<n> <unit>"
+
+	// Units maps the English singular unit ("minute", "hour", "day", "month",
+	// "year") to its singular and plural forms.
+	Units map[string][2]string
+
+	// Plural reports whether n takes the plural form.
+	Plural func(n int64) bool
+
+	// Pluralize, if set, replaces Units and Plural for languages with more
+	// than two forms. It receives the English singular unit and the count and
+	// returns the word to print.
+	Pluralize func(unit string, n int) string
+
+	Dates DateWords // Relative day phrases for HumanDateLocale
+	Order DateOrder // Order of full dates for HumanDateLocale and DateRangeLocale
+
+	Names // Month and weekday names for date formatting
+}
+
+// DateWords holds the phrases HumanDate uses for days near the reference.
+type DateWords struct {
+	Today, Yesterday, Tomorrow string
+	EarlierToday, LaterToday   string
+
+	// Formats for a day within a week of the reference; %s receives the
+	// weekday name.
+	Last, This, Next string
+}
+
+// englishWords holds HumanDate's English phrases.
+var englishWords = DateWords{
+	Today:        "Today",
+	Yesterday:    "Yesterday",
+	Tomorrow:     "Tomorrow",
+	EarlierToday: "Earlier today",
+	LaterToday:   "Later today",
+	Last:         "Last %s",
+	This:         "This %s",
+	Next:         "Next %s",
+}
+
+// Names holds the month and weekday names used by the date formatters.
+type Names struct {
+	Months        [12]string // January first
+	Weekdays      [7]string  // Sunday first, matching time.Weekday
+	ShortWeekdays [7]string  // Abbreviated weekdays, Sunday first
+	ShortMonths   [12]string // Abbreviated months, January first
+}
+
+// has reports whether word is one of n's month or weekday names, full or
+// abbreviated. A nil table has no names.
+func (n *Names) has(word string) bool {
+	if n == nil {
+		return false
+	}
+	for _, list := range [][]string{n.Months[:], n.ShortMonths[:], n.Weekdays[:], n.ShortWeekdays[:]} {
+		for _, name := range list {
+			if name != "" && name == word {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// englishNames holds full English month and weekday names.
+var englishNames = Names{
+	Months: [12]string{"January", "February", "March", "April", "May", "June",
+		"July", "August", "September", "October", "November", "December"},
+	Weekdays:      [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+	ShortWeekdays: [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+	ShortMonths:   [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+}
+
+// EnglishAbbrevNames holds three-letter English month and weekday names.
+var EnglishAbbrevNames = Names{
+	Months:        [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+	Weekdays:      [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+	ShortWeekdays: [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+	ShortMonths:   [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+}
+
+// EnglishNames returns a copy of the full English month and weekday names,
+// the date formatters' default.
+func EnglishNames() Names {
+	return englishNames
+}
+
+// english is the default locale. It is unexported so callers cannot change
+// the words the default formatters use; English returns a copy.
+var english = Locale{
+	JustNow: "just now",
+	Past:    "%s ago",
+	Future:  "in %s",
+	Units: map[string][2]string{
+		"second": {"second", "seconds"},
+		"minute": {"minute", "minutes"},
+		"hour":   {"hour", "hours"},
+		"day":    {"day", "days"},
+		"week":   {"week", "weeks"},
+		"month":  {"month", "months"},
+		"year":   {"year", "years"},
+	},
+	Plural: func(n int64) bool { return n != 1 },
+	Dates:  englishWords,
+	Names:  englishNames,
+}
+
+// English returns a copy of the default locale that is safe to modify.
+func English() Locale {
+	return english.clone()
+}
+
+// clone returns loc with its own copy of the Units map.
+func (loc Locale) clone() Locale {
+	loc.Units = maps.Clone(loc.Units)
+	return loc
+}
+
+var locales = map[string]Locale{
+	"en": english,
+	"fr": {
+		JustNow: "à l'instant",
+		Past:    "il y a %s",
+		Future:  "dans %s",
+		Units: map[string][2]string{
+			"second": {"seconde", "secondes"},
+			"minute": {"minute", "minutes"},
+			"hour":   {"heure", "heures"},
+			"day":    {"jour", "jours"},
+			"week":   {"semaine", "semaines"},
+			"month":  {"mois", "mois"},
+			"year":   {"an", "ans"},
+		},
+		Plural: func(n int64) bool { return n > 1 }, // 0 and 1 are singular
+		Dates: DateWords{
+			Today:        "Aujourd'hui",
+			Yesterday:    "Hier",
+			Tomorrow:     "Demain",
+			EarlierToday: "Plus tôt aujourd'hui",
+			LaterToday:   "Plus tard aujourd'hui",
+			Last:         "%s dernier",
+			This:         "Ce %s",
+			Next:         "%s prochain",
+		},
+		Order: DMY,
+		Names: Names{
+			Months: [12]string{"janvier", "février", "mars", "avril", "mai", "juin",
+				"juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+			Weekdays:      [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+			ShortWeekdays: [7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+		},
+	},
+	"es": {
+		JustNow: "justo ahora",
+		Past:    "hace %s",
+		Future:  "en %s",
+		Units: map[string][2]string{
+			"second": {"segundo", "segundos"},
+			"minute": {"minuto", "minutos"},
+			"hour":   {"hora", "horas"},
+			"day":    {"día", "días"},
+			"week":   {"semana", "semanas"},
+			"month":  {"mes", "meses"},
+			"year":   {"año", "años"},
+		},
+		Plural: func(n int64) bool { return n != 1 },
+		Dates: DateWords{
+			Today:        "Hoy",
+			Yesterday:    "Ayer",
+			Tomorrow:     "Mañana",
+			EarlierToday: "Hoy más temprano",
+			LaterToday:   "Hoy más tarde",
+			Last:         "El %s pasado",
+			This:         "Este %s",
+			Next:         "El próximo %s",
+		},
+		Order: DMY,
+		Names: Names{
+			Months: [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio",
+				"julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+			Weekdays:      [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+			ShortWeekdays: [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+		},
+	},
+	"de": {
+		JustNow: "gerade eben",
+		Past:    "vor %s",
+		Future:  "in %s",
+		// Both "vor" and "in" take the dative, hence "Tagen" rather than "Tage".
+		Units: map[string][2]string{
+			"second": {"Sekunde", "Sekunden"},
+			"minute": {"Minute", "Minuten"},
+			"hour":   {"Stunde", "Stunden"},
+			"day":    {"Tag", "Tagen"},
+			"week":   {"Woche", "Wochen"},
+			"month":  {"Monat", "Monaten"},
+			"year":   {"Jahr", "Jahren"},
+		},
+		Plural: func(n int64) bool { return n != 1 },
+		Dates: DateWords{
+			Today:        "Heute",
+			Yesterday:    "Gestern",
+			Tomorrow:     "Morgen",
+			EarlierToday: "Heute früher",
+			LaterToday:   "Heute später",
+			Last:         "Letzten %s",
+			This:         "Diesen %s",
+			Next:         "Nächsten %s",
+		},
+		Order: DMY,
+		Names: Names{
+			Months: [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni",
+				"Juli", "August", "September", "Oktober", "November", "Dezember"},
+			Weekdays:      [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+			ShortWeekdays: [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+		},
+	},
+}
+
+// localesMu guards locales, which RegisterLocale may change while
+// formatters read it.
+var localesMu sync.RWMutex
+
+// RegisterLocale makes loc available under a language tag such as "it".
+// Registering an existing tag replaces it. A nil Plural defaults to the
+// English rule (plural unless n is 1). The registry keeps its own copy of
+// Units, so later changes to loc do not reach it. It is safe to call
+// concurrently with LookupLocale.
+func RegisterLocale(tag string, loc Locale) {
+	if loc.Plural == nil {
+		loc.Plural = english.Plural
+	}
+	localesMu.Lock()
+	defer localesMu.Unlock()
+	locales[tag] = loc.clone()
+}
+
+// LookupLocale returns the locale registered under tag. Built-in tags are
+// "en", "fr", "es", and "de". The result is a copy that is safe to modify.
+func LookupLocale(tag string) (Locale, bool) {
+	localesMu.RLock()
+	defer localesMu.RUnlock()
+	loc, ok := locales[tag]
+	return loc.clone(), ok
+}
+
+// unitWord returns the singular or plural form of unit for n in loc. A nil
+// Plural uses the English rule.
+func (loc Locale) unitWord(unit string, n int64) string {
+	if loc.Pluralize != nil {
+		return loc.Pluralize(unit, int(n))
+	}
+	plural := loc.Plural
+	if plural == nil {
+		plural = english.Plural
+	}
+	forms := loc.Units[unit]
+	if plural(n) {
+		return forms[1]
+	}
+	return forms[0]
+}
+
+// TimeAgoLocale is TimeAgo with words and plural rules taken from loc.
+func TimeAgoLocale(timestamp, reference int64, loc Locale) string {
+	return timeAgo(reference-timestamp, loc, TimeAgoOptions{})
+}
+
+// dateOptions returns DateOptions that render dates with loc's names and
+// order. A locale without Dates keeps the English phrases.
+func (loc Locale) dateOptions() DateOptions {
+	opts := DateOptions{Names: &loc.Names, Order: loc.Order}
+	if loc.Dates != (DateWords{}) {
+		opts.Words = &loc.Dates
+	}
+	return opts
+}
+
+// HumanDateLocale is HumanDate with phrases, names, and date order taken
+// from loc: "Hier", "Samedi dernier", "5 mars".
+func HumanDateLocale(timestamp, reference int64, loc Locale) string {
+	return HumanDateWith(timestamp, reference, loc.dateOptions())
+}
+
+// DateRangeLocale is DateRange with names and date order taken from loc:
+// "5–7 mars 2024".
+func DateRangeLocale(start, end int64, loc Locale) string {
+	return DateRangeWith(start, end, loc.dateOptions())
+}
+
+// TimeAgoParts returns the pieces TimeAgo formats: the rounded value, the
+// singular English unit ("minute" through "year"), and whether the timestamp
+// is in the future. justNow reports a difference under 45 seconds, in which
+// case value and unit are empty.
+func TimeAgoParts(timestamp, reference int64) (value int, unit string, future bool, justNow bool) {
+	v, unit, future, justNow := timeAgoParts(reference-timestamp, TimeAgoOptions{})
+	return int(v), unit, future, justNow
+}
+
+// compactUnits abbreviates TimeAgo's units for TimeAgoCompact.
+var compactUnits = map[string]string{
+	"minute": "m",
+	"hour":   "h",
+	"day":    "d",
+	"week":   "w",
+	"month":  "mo",
+	"year":   "y",
+}
+
+// TimeAgoCompact is TimeAgo for dense tables: "-3h" for three hours ago,
+// "+2d" for two days ahead, and "now" within the "just now" window.
+func TimeAgoCompact(timestamp, reference int64) string {
+	value, unit, future, justNow := TimeAgoParts(timestamp, reference)
+	if justNow {
+		return "now"
+	}
+	sign := "-"
+	if future {
+		sign = "+"
+	}
+	return fmt.Sprintf("%s%d%s", sign, value, compactUnits[unit])
+}
+
+// timeAgoParts implements TimeAgoParts for diff, the seconds from the event
+// to the reference, so a negative diff is in the future.
+func timeAgoParts(diff int64, opts TimeAgoOptions) (value int64, unit string, future bool, justNow bool) {
+	future = diff < 0
+	if diff < 0 {
+		diff = -diff
+	}
+
+	value, unit, ok := relativeUnit(diff, opts.Thresholds)
+	if !ok {
+		return 0, "", future, true
+	}
+	if opts.UseWeeks && diff > opts.Thresholds.weekCutoff() {
+		if days := math.Round(float64(diff) / 86400); days >= 7 && days <= 27 {
+			value, unit = int64(math.Round(days/7)), "week"
+		}
+	}
+	if capSecs, ok := relativeUnitSeconds[opts.MaxUnit]; ok && relativeUnitSeconds[unit] > capSecs {
+		value, unit = int64(math.Round(float64(diff)/float64(capSecs))), opts.MaxUnit
+	}
+	return value, unit, future, false
+}
+
+// timeAgo formats diff, the seconds from the event to the reference, so a
+// negative diff is in the future.
+func timeAgo(diff int64, loc Locale, opts TimeAgoOptions) string {
+	value, unit, future, justNow := timeAgoParts(diff, opts)
+	switch {
+	case justNow && future && opts.SoonText != "":
+		return opts.SoonText
+	case justNow && !future && opts.JustNowText != "":
+		return opts.JustNowText
+	case justNow:
+		return loc.JustNow
+	}
+	if opts.DayWords && unit == "day" && value == 1 {
+		if future {
+			return "tomorrow"
+		}
+		return "yesterday"
+	}
+	if opts.Pluralize != nil {
+		loc.Pluralize = opts.Pluralize
+	}
+
+	amount := fmt.Sprintf("%d %s", value, loc.unitWord(unit, value))
+	if opts.UseArticles && value == 1 {
+		amount = article(unit) + " " + loc.unitWord(unit, value)
+	}
+	if future {
+		return fmt.Sprintf(loc.Future, amount)
 	}
+	return fmt.Sprintf(loc.Past, amount)
 }
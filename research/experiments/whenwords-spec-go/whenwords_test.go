@@ -1,7 +1,9 @@
 package whenwords
 
 import (
+	"math"
 	"testing"
+	"time"
 )
 
 func TestTimeAgoPast(t *testing.T) {
@@ -111,6 +113,12 @@ func TestDuration(t *testing.T) {
 		{"93600 max1", 93600, false, 1, "1 day"},
 		{"93661 max3", 93661, false, 3, "1 day, 2 hours, 1 minute"},
 		{"9000 compact max1", 9000, true, 1, "3h"},
+		// Spec step 3 rounds the last slot without carrying.
+		{"3599 max1", 3599, false, 1, "60 minutes"},
+		{"7199 default", 7199, false, 2, "1 hour, 60 minutes"},
+		{"86399 default", 86399, false, 2, "23 hours, 60 minutes"},
+		{"2591999 default", 2591999, false, 2, "29 days, 24 hours"},
+		{"31535999 default", 31535999, false, 2, "12 months, 5 days"},
 	}
 
 	for _, tt := range tests {
@@ -166,6 +174,59 @@ func TestParseDuration(t *testing.T) {
 		{"0:05:30", 330},
 		{"2H 30M", 9000},
 		{"  2 hours   30 minutes  ", 9000},
+		{"PT2H30M", 9000},
+		{"P1D", 86400},
+		{"PT45S", 45},
+		{"P1DT6H", 108000},
+		{"P2W", 1209600},
+		{"PT1.5H", 5400},
+		{"1.5s", 2},
+		{"250ms", 0},
+		{"1500ms", 2},
+		{"2m 500ms", 121},
+		{"0:0:30.5", 31},
+		{"0:00:30.4", 30},
+		{"1:30:00.5", 5401},
+		{"1h30", 5400},
+		{"2m15", 135},
+		{"1 hour 30", 5400},
+		{"1d12", 129600},
+		{"2h30m", 9000},
+		{"half an hour", 1800},
+		{"an hour and a half", 5400},
+		{"quarter of an hour", 900},
+		{"a quarter hour", 900},
+		{"a half hour", 1800},
+		{"an hour", 3600},
+		{"a day and 2 hours", 93600},
+		{"2 hours and a half", 9000},
+		{"Half an Hour", 1800},
+		{"two hours", 7200},
+		{"ninety minutes", 5400},
+		{"one hour and fifteen minutes", 4500},
+		{"two hours thirty minutes", 9000},
+		{"twenty-five seconds", 25},
+		{"one hundred twenty minutes", 7200},
+		{"one hour and a half", 5400},
+		{"a day and a half", 129600},
+		{"two hours and a half", 9000},
+		{"an hour and a quarter", 4500},
+		{"a day and a quarter", 108000},
+		{"1.5 hours and a quarter", 6300},
+		{"a week and a half", 907200},
+		{"2 days and a half and 3 hours", 226800},
+		{"1.5 hours", 5400},
+		{"1.5d", 129600},
+		{"0.5w", 302400},
+		{"2.5mo", 6480000},
+		{".5h", 1800},
+		{".25 days", 21600},
+		{"1h .5m", 3630},
+		{"1 fortnight", 1209600},
+		{"2 fortnights", 2419200},
+		{"1 decade", 315360000},
+		{"2 decades", 630720000},
+		{"a fortnight and 2 days", 1382400},
 	}
 
 	for _, tt := range tests {
@@ -191,6 +252,19 @@ func TestParseDurationErrors(t *testing.T) {
 		{"-5 hours"},
 		{"42"},
 		{"5 foos"},
+		{"P2X"},
+		{"P"},
+		{"P1DT"},
+		{"PT2H 30 minutes"},
+		{"a"},
+		{"half"},
+		{"a foo"},
+		{"two"},
+		{"1:30.5"},
+		{"1:30:00."},
+		{"90"},
+		{"30s 5"},
+		{".5"},
 	}
 
 	for _, tt := range tests {
@@ -266,3 +340,1927 @@ func TestDateRange(t *testing.T) {
 		})
 	}
 }
+
+func TestTimeAgoLocale(t *testing.T) {
+	ref := int64(1704067200) // 2024-01-01 00:00:00 UTC
+	fr, _ := LookupLocale("fr")
+	de, _ := LookupLocale("de")
+
+	tests := []struct {
+		name      string
+		loc       Locale
+		timestamp int64
+		expected  string
+	}{
+		{"fr just now", fr, ref - 10, "à l'instant"},
+		{"fr 1 minute past", fr, ref - 60, "il y a 1 minute"},
+		{"fr 30 minutes past", fr, ref - 1800, "il y a 30 minutes"},
+		{"fr 5 hours past", fr, ref - 18000, "il y a 5 heures"},
+		{"fr 1 hour future", fr, ref + 3600, "dans 1 heure"},
+		{"fr 2 days future", fr, ref + 172800, "dans 2 jours"},
+		{"fr 6 months past", fr, ref - 15552000, "il y a 6 mois"},
+		{"de 1 minute future", de, ref + 60, "in 1 Minute"},
+		{"de 10 minutes future", de, ref + 600, "in 10 Minuten"},
+		{"de 3 hours past", de, ref - 10800, "vor 3 Stunden"},
+		{"de 2 days past", de, ref - 172800, "vor 2 Tagen"},
+		{"en default", English(), ref - 7200, "2 hours ago"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TimeAgoLocale(tt.timestamp, ref, tt.loc)
+			if got != tt.expected {
+				t.Errorf("TimeAgoLocale(%d, %d) = %q, want %q", tt.timestamp, ref, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRegisterLocale(t *testing.T) {
+	it := English()
+	it.JustNow = "adesso"
+	it.Past = "%s fa"
+	it.Future = "tra %s"
+	it.Units = map[string][2]string{"minute": {"minuto", "minuti"}, "hour": {"ora", "ore"}}
+	RegisterLocale("it", it)
+	defer delete(locales, "it")
+
+	loc, ok := LookupLocale("it")
+	if !ok {
+		t.Fatal("LookupLocale(\"it\") not found after RegisterLocale")
+	}
+	ref := int64(1704067200)
+	if got := TimeAgoLocale(ref-7200, ref, loc); got != "2 ore fa" {
+		t.Errorf("TimeAgoLocale = %q, want %q", got, "2 ore fa")
+	}
+	if _, ok := LookupLocale("xx"); ok {
+		t.Error("LookupLocale(\"xx\") should not be found")
+	}
+}
+
+func TestRegisterLocaleWithoutPlural(t *testing.T) {
+	nl := Locale{
+		JustNow: "zojuist",
+		Past:    "%s geleden",
+		Future:  "over %s",
+		Units:   map[string][2]string{"hour": {"uur", "uur"}, "minute": {"minuut", "minuten"}},
+	}
+	RegisterLocale("nl", nl)
+	defer delete(locales, "nl")
+
+	loc, _ := LookupLocale("nl")
+	ref := int64(1704067200)
+	if got := TimeAgoLocale(ref-600, ref, loc); got != "10 minuten geleden" {
+		t.Errorf("TimeAgoLocale = %q, want %q", got, "10 minuten geleden")
+	}
+	// An unregistered locale without Plural falls back to the English rule too.
+	if got := TimeAgoLocale(ref-60, ref, nl); got != "1 minuut geleden" {
+		t.Errorf("TimeAgoLocale = %q, want %q", got, "1 minuut geleden")
+	}
+}
+
+func TestRegisterLocaleConcurrent(t *testing.T) {
+	defer delete(locales, "xx-test")
+	done := make(chan bool)
+	go func() {
+		for i := 0; i < 100; i++ {
+			RegisterLocale("xx-test", English())
+		}
+		done <- true
+	}()
+	for i := 0; i < 100; i++ {
+		LookupLocale("fr")
+	}
+	<-done
+}
+
+func TestLocaleCopies(t *testing.T) {
+	ref := int64(1704067200)
+
+	en := English()
+	en.Units["hour"] = [2]string{"heure", "heures"}
+	fr, _ := LookupLocale("fr")
+	fr.Units["hour"] = [2]string{"hour", "hours"}
+	names := EnglishNames()
+	names.Weekdays[time.Monday] = "Lundi"
+
+	if got := TimeAgo(ref-7200, ref); got != "2 hours ago" {
+		t.Errorf("TimeAgo after changing English() = %q, want %q", got, "2 hours ago")
+	}
+	if got := HumanDate(1705276800, 1705449600); got != "Last Monday" {
+		t.Errorf("HumanDate after changing EnglishNames() = %q, want %q", got, "Last Monday")
+	}
+	fr, _ = LookupLocale("fr")
+	if got := TimeAgoLocale(ref-7200, ref, fr); got != "il y a 2 heures" {
+		t.Errorf("TimeAgoLocale after changing a looked-up locale = %q, want %q", got, "il y a 2 heures")
+	}
+
+	// Changing a map after registering it leaves the registered copy alone.
+	defer delete(locales, "xx-copy")
+	RegisterLocale("xx-copy", en)
+	en.Units["hour"] = [2]string{"uur", "uur"}
+	loc, _ := LookupLocale("xx-copy")
+	if got := TimeAgoLocale(ref-7200, ref, loc); got != "2 heures ago" {
+		t.Errorf("TimeAgoLocale after changing a registered map = %q, want %q", got, "2 heures ago")
+	}
+}
+
+func TestHumanDateLocale(t *testing.T) {
+	ref := int64(1705276800) // 2024-01-15 Monday 00:00 UTC
+	fr, _ := LookupLocale("fr")
+	es, _ := LookupLocale("es")
+	de, _ := LookupLocale("de")
+	nl := Locale{Units: map[string][2]string{}, Names: EnglishNames()}
+
+	tests := []struct {
+		name     string
+		got      string
+		expected string
+	}{
+		{"fr today", HumanDateLocale(ref, ref, fr), "Aujourd'hui"},
+		{"fr yesterday", HumanDateLocale(ref-86400, ref, fr), "Hier"},
+		{"fr last saturday", HumanDateLocale(1705104000, ref, fr), "Samedi dernier"},
+		{"fr this wednesday", HumanDateLocale(1705449600, ref, fr), "Ce mercredi"},
+		{"fr same year", HumanDateLocale(1709596800, ref, fr), "5 mars"},
+		{"fr other year", HumanDateLocale(1672531200, ref, fr), "1 janvier 2023"},
+		{"es tomorrow", HumanDateLocale(ref+86400, ref, es), "Mañana"},
+		{"es last saturday", HumanDateLocale(1705104000, ref, es), "El sábado pasado"},
+		{"de last saturday", HumanDateLocale(1705104000, ref, de), "Letzten Samstag"},
+		{"de same year", HumanDateLocale(1709596800, ref, de), "5 März"},
+		{"en", HumanDateLocale(1705104000, ref, English()), "Last Saturday"},
+		{"no dates keeps english", HumanDateLocale(ref-86400, ref, nl), "Yesterday"},
+		{"fr range same month", DateRangeLocale(1709596800, 1709769600, fr), "5–7 mars 2024"},
+		{"de range same year", DateRangeLocale(1705276800, 1707955200, de), "15 Januar – 15 Februar 2024"},
+		{"en range", DateRangeLocale(1705276800, 1707955200, English()), "January 15 – February 15, 2024"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.expected {
+				t.Errorf("got %q, want %q", tt.got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHumanDateIn(t *testing.T) {
+	pst := time.FixedZone("PST", -8*3600)
+	ref := int64(1705305600) // 2024-01-15 08:00 UTC, 00:00 in -08:00
+
+	tests := []struct {
+		name      string
+		timestamp int64
+		loc       *time.Location
+		expected  string
+	}{
+		{"utc same day", 1705291200, time.UTC, "Today"},        // 2024-01-15 04:00 UTC
+		{"pst previous day", 1705291200, pst, "Yesterday"},     // 2024-01-14 20:00 PST
+		{"utc next day", 1705363200, time.UTC, "Tomorrow"},     // 2024-01-16 00:00 UTC
+		{"pst same day", 1705363200, pst, "Today"},             // 2024-01-15 16:00 PST
+		{"pst last weekday", 1705132800, pst, "Last Saturday"}, // 2024-01-13 00:00 PST
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := HumanDateIn(tt.timestamp, ref, tt.loc)
+			if got != tt.expected {
+				t.Errorf("HumanDateIn(%d, %d, %s) = %q, want %q", tt.timestamp, ref, tt.loc, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTimeAgoPrecise(t *testing.T) {
+	ref := int64(1704067200) // 2024-01-01 00:00:00 UTC
+
+	tests := []struct {
+		timestamp int64
+		maxUnits  int
+		expected  string
+	}{
+		{ref - 8100, 2, "2 hours 15 minutes ago"},
+		{ref - 8130, 2, "2 hours 16 minutes ago"},
+		{ref - 8130, 3, "2 hours 15 minutes 30 seconds ago"},
+		{ref - 8100, 1, "2 hours ago"},
+		{ref + 90000, 2, "in 1 day 1 hour"},
+		{ref + 30, 2, "in 30 seconds"},
+		{ref, 2, "just now"},
+		{ref - 7199, 2, "2 hours ago"},
+		{ref - 86399, 2, "1 day ago"},
+		{ref + 7199, 2, "in 2 hours"},
+		{ref - 8100, 0, "2 hours ago"},
+		{ref - 8100, -1, "2 hours ago"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			got := TimeAgoPrecise(tt.timestamp, ref, tt.maxUnits)
+			if got != tt.expected {
+				t.Errorf("TimeAgoPrecise(%d, %d, %d) = %q, want %q", tt.timestamp, ref, tt.maxUnits, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseGoDuration(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected time.Duration
+	}{
+		{"2h30m", 2*time.Hour + 30*time.Minute},
+		{"1.5 seconds", 1500 * time.Millisecond},
+		{"1:30:00", 90 * time.Minute},
+		{"PT45S", 45 * time.Second},
+		{"250ms", 250 * time.Millisecond},
+		{"1s 500ms", 1500 * time.Millisecond},
+		{"750 microseconds", 750 * time.Microsecond},
+		{"20ns", 20 * time.Nanosecond},
+		{"0:0:30.5", 30*time.Second + 500*time.Millisecond},
+		{"1:30", 90 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseGoDuration(tt.input)
+			if err != nil {
+				t.Fatalf("ParseGoDuration(%q) returned error: %v", tt.input, err)
+			}
+			if got != tt.expected {
+				t.Errorf("ParseGoDuration(%q) = %v, want %v", tt.input, got, tt.expected)
+			}
+		})
+	}
+
+	for _, input := range []string{"", "42", "5 foos", "-5 hours"} {
+		if _, err := ParseGoDuration(input); err == nil {
+			t.Errorf("ParseGoDuration(%q) should have returned error", input)
+		}
+	}
+}
+
+func TestDurationFine(t *testing.T) {
+	tests := []struct {
+		d        time.Duration
+		compact  bool
+		maxUnits int
+		expected string
+	}{
+		{500 * time.Millisecond, true, 2, "500ms"},
+		{time.Minute + 500*time.Millisecond, true, 2, "1m 500ms"},
+		{time.Minute + 500*time.Millisecond, false, 2, "1 minute, 500 milliseconds"},
+		{1500 * time.Millisecond, true, 2, "1s 500ms"},
+		{1500 * time.Millisecond, true, 1, "2s"},
+		{2*time.Hour + 30*time.Minute, true, 2, "2h 30m"},
+		{250 * time.Microsecond, false, 2, "250 microseconds"},
+		{1 * time.Nanosecond, false, 2, "1 nanosecond"},
+		{0, true, 2, "0s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			got := DurationFine(tt.d, tt.compact, tt.maxUnits)
+			if got != tt.expected {
+				t.Errorf("DurationFine(%v, %v, %d) = %q, want %q", tt.d, tt.compact, tt.maxUnits, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHumanDateTime(t *testing.T) {
+	ref := int64(1705276800) // 2024-01-15 Monday 00:00 UTC
+
+	tests := []struct {
+		timestamp int64
+		opts      DateOptions
+		expected  string
+	}{
+		{1705331100, DateOptions{}, "Today at 3:05 PM"},
+		{1705331100, DateOptions{Clock24: true}, "Today at 15:05"},
+		{1705222800, DateOptions{Clock24: true}, "Yesterday at 09:00"},
+		{1705363200 + 30600, DateOptions{}, "Tomorrow at 8:30 AM"},
+		{1705363200 + 32400, DateOptions{}, "Tomorrow at 9:00 AM"},
+		{1705190400 + 73800, DateOptions{}, "Yesterday at 8:30 PM"},
+		{1705104000 + 50400, DateOptions{}, "Last Saturday at 2:00 PM"},
+		{1705449600 + 50400, DateOptions{}, "This Wednesday at 2:00 PM"},
+		{1705017600, DateOptions{}, "Last Friday at 12:00 AM"},
+		{1709251200 + 43200, DateOptions{}, "March 1, 12:00 PM"},
+		{1672531200 + 32400, DateOptions{}, "January 1, 2023, 9:00 AM"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			got := HumanDateTime(tt.timestamp, ref, tt.opts)
+			if got != tt.expected {
+				t.Errorf("HumanDateTime(%d, %d, %+v) = %q, want %q", tt.timestamp, ref, tt.opts, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDateTimeRange(t *testing.T) {
+	day := int64(1709596800) // 2024-03-05 00:00 UTC
+
+	tests := []struct {
+		start    int64
+		end      int64
+		expected string
+	}{
+		{day + 32400, day + 64800, "March 5, 2024, 9:00 AM – 6:00 PM"},
+		{day + 64800, day + 32400, "March 5, 2024, 9:00 AM – 6:00 PM"},
+		{day + 32400, day + 32400, "March 5, 2024, 9:00 AM"},
+		{day + 72000, day + 86400 + 36000, "March 5, 8:00 PM – March 6, 2024, 10:00 AM"},
+		{1703980800 + 79200, 1704067200 + 7200, "December 31, 2023, 10:00 PM – January 1, 2024, 2:00 AM"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			got := DateTimeRange(tt.start, tt.end)
+			if got != tt.expected {
+				t.Errorf("DateTimeRange(%d, %d) = %q, want %q", tt.start, tt.end, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestOrdinal(t *testing.T) {
+	tests := []struct {
+		n        int
+		expected string
+	}{
+		{1, "1st"}, {2, "2nd"}, {3, "3rd"}, {4, "4th"},
+		{11, "11th"}, {12, "12th"}, {13, "13th"},
+		{21, "21st"}, {22, "22nd"}, {23, "23rd"}, {30, "30th"},
+		{101, "101st"}, {111, "111th"}, {0, "0th"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if got := Ordinal(tt.n); got != tt.expected {
+				t.Errorf("Ordinal(%d) = %q, want %q", tt.n, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestOrdinalDates(t *testing.T) {
+	ref := int64(1705276800) // 2024-01-15 Monday 00:00 UTC
+	opts := DateOptions{Ordinal: true}
+
+	if got := HumanDateWith(1709251200, ref, opts); got != "March 1st" {
+		t.Errorf("HumanDateWith = %q, want %q", got, "March 1st")
+	}
+	if got := HumanDateWith(1672531200, ref, opts); got != "January 1st, 2023" {
+		t.Errorf("HumanDateWith = %q, want %q", got, "January 1st, 2023")
+	}
+	if got := HumanDateWith(1705190400, ref, opts); got != "Yesterday" {
+		t.Errorf("HumanDateWith = %q, want %q", got, "Yesterday")
+	}
+	if got := DateRangeWith(1705276800, 1705881600, opts); got != "January 15th–22nd, 2024" {
+		t.Errorf("DateRangeWith = %q, want %q", got, "January 15th–22nd, 2024")
+	}
+	if got := DateRangeWith(1703721600, 1705276800, opts); got != "December 28th, 2023 – January 15th, 2024" {
+		t.Errorf("DateRangeWith = %q, want %q", got, "December 28th, 2023 – January 15th, 2024")
+	}
+}
+
+func TestTimeOverloads(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*3600)
+	nyc := time.FixedZone("EST", -5*3600)
+
+	// 2024-01-15 23:30 JST is 2024-01-15 14:30 UTC.
+	now := time.Date(2024, 1, 15, 23, 30, 0, 0, tokyo)
+
+	if got := TimeAgoT(now.Add(-3*time.Hour), now.In(nyc)); got != "3 hours ago" {
+		t.Errorf("TimeAgoT = %q, want %q", got, "3 hours ago")
+	}
+	if got := TimeAgoT(now.Add(2*24*time.Hour), now); got != "in 2 days" {
+		t.Errorf("TimeAgoT = %q, want %q", got, "in 2 days")
+	}
+
+	// One hour later is already January 16 in Tokyo but not in UTC.
+	later := now.Add(time.Hour)
+	if got := HumanDateT(later, now); got != "Tomorrow" {
+		t.Errorf("HumanDateT = %q, want %q", got, "Tomorrow")
+	}
+	if got := HumanDateT(later.UTC(), now.UTC()); got != "Today" {
+		t.Errorf("HumanDateT in UTC = %q, want %q", got, "Today")
+	}
+
+	start := time.Date(2024, 3, 5, 22, 0, 0, 0, nyc)
+	end := time.Date(2024, 3, 9, 8, 0, 0, 0, nyc)
+	if got := DateRangeT(start, end); got != "March 5–9, 2024" {
+		t.Errorf("DateRangeT = %q, want %q", got, "March 5–9, 2024")
+	}
+	if got := DateRangeT(end, start); got != "March 5–9, 2024" {
+		t.Errorf("DateRangeT reversed = %q, want %q", got, "March 5–9, 2024")
+	}
+	if got := DateRange(start.Unix(), end.Unix()); got != "March 6–9, 2024" {
+		t.Errorf("DateRange = %q, want %q", got, "March 6–9, 2024")
+	}
+}
+
+func TestTimeAgoWeeks(t *testing.T) {
+	ref := int64(1704067200) // 2024-01-01 00:00:00 UTC
+	day := int64(86400)
+	weeks := TimeAgoOptions{UseWeeks: true}
+
+	tests := []struct {
+		timestamp int64
+		opts      TimeAgoOptions
+		expected  string
+	}{
+		{ref - 6*day, weeks, "6 days ago"},
+		{ref - 7*day, weeks, "1 week ago"},
+		{ref - 10*day, weeks, "1 week ago"},
+		{ref - 11*day, weeks, "2 weeks ago"},
+		{ref - 21*day, weeks, "3 weeks ago"},
+		{ref - 27*day, weeks, "4 weeks ago"},
+		{ref - 28*day, weeks, "1 month ago"},
+		{ref + 14*day, weeks, "in 2 weeks"},
+		{ref - day, weeks, "1 day ago"},
+		{ref - 10*day, TimeAgoOptions{}, "10 days ago"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			got := TimeAgoWith(tt.timestamp, ref, tt.opts)
+			if got != tt.expected {
+				t.Errorf("TimeAgoWith(%d, %d, %+v) = %q, want %q", tt.timestamp, ref, tt.opts, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBusinessDaysBetween(t *testing.T) {
+	mon := int64(1705276800) // 2024-01-15 Monday 00:00 UTC
+	day := int64(86400)
+
+	tests := []struct {
+		name     string
+		start    int64
+		end      int64
+		expected int
+	}{
+		{"same instant", mon, mon, 0},
+		{"single weekday", mon, mon + day, 1},
+		{"full week", mon, mon + 7*day, 5},
+		{"friday over weekend", mon + 4*day, mon + 7*day, 1},
+		{"thursday to tuesday", mon + 3*day, mon + 8*day, 3},
+		{"weekend only", mon + 5*day, mon + 7*day, 0},
+		{"two weeks and a day", mon, mon + 15*day, 11},
+		{"reversed", mon + 7*day, mon, 5},
+		{"times within days", mon + 3600, mon + day + 7200, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BusinessDaysBetween(tt.start, tt.end); got != tt.expected {
+				t.Errorf("BusinessDaysBetween(%d, %d) = %d, want %d", tt.start, tt.end, got, tt.expected)
+			}
+		})
+	}
+
+	// Friday 20:00 in -08:00 is Saturday 04:00 UTC.
+	pst := time.FixedZone("PST", -8*3600)
+	fri := mon + 4*day + 28*3600
+	if got := BusinessDaysBetweenIn(fri, fri+day, pst); got != 1 {
+		t.Errorf("BusinessDaysBetweenIn PST = %d, want 1", got)
+	}
+	if got := BusinessDaysBetween(fri, fri+day); got != 0 {
+		t.Errorf("BusinessDaysBetween UTC = %d, want 0", got)
+	}
+}
+
+func TestCountdown(t *testing.T) {
+	now := int64(1704067200) // 2024-01-01 00:00:00 UTC
+
+	tests := []struct {
+		target   int64
+		maxUnits int
+		expected string
+	}{
+		{now + 3*86400 + 4*3600 + 600, 2, "3 days, 4 hours remaining"},
+		{now + 300, 2, "5 minutes remaining"},
+		{now + 3661, 3, "1 hour, 1 minute, 1 second remaining"},
+		{now, 2, "ended"},
+		{now - 3600, 2, "ended"},
+		{now + 7199, 2, "2 hours remaining"},
+		{now + 86399, 2, "1 day remaining"},
+		{now + 300, 0, "5 minutes remaining"},
+		{now + 3*86400 + 4*3600, 0, "3 days remaining"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if got := Countdown(tt.target, now, tt.maxUnits); got != tt.expected {
+				t.Errorf("Countdown(%d, %d, %d) = %q, want %q", tt.target, now, tt.maxUnits, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCountdownCarryLeavesDuration(t *testing.T) {
+	// Countdown carries on its own path; Duration keeps the spec's output.
+	now := int64(1704067200)
+	if got := Countdown(now+7199, now, 2); got != "2 hours remaining" {
+		t.Errorf("Countdown = %q, want %q", got, "2 hours remaining")
+	}
+	if got := Duration(7199, false, 2); got != "1 hour, 60 minutes" {
+		t.Errorf("Duration = %q, want %q", got, "1 hour, 60 minutes")
+	}
+}
+
+func TestAgeYears(t *testing.T) {
+	unix := func(y int, m time.Month, d int) int64 {
+		return time.Date(y, m, d, 12, 0, 0, 0, time.UTC).Unix()
+	}
+
+	tests := []struct {
+		name     string
+		birth    int64
+		now      int64
+		expected int
+	}{
+		{"birthday today", unix(1990, 3, 5), unix(2024, 3, 5), 34},
+		{"day before birthday", unix(1990, 3, 5), unix(2024, 3, 4), 33},
+		{"day after birthday", unix(1990, 3, 5), unix(2024, 3, 6), 34},
+		{"leap day in leap year", unix(2000, 2, 29), unix(2024, 2, 29), 24},
+		{"leap day before march", unix(2000, 2, 29), unix(2023, 2, 28), 22},
+		{"leap day on march 1", unix(2000, 2, 29), unix(2023, 3, 1), 23},
+		{"newborn", unix(2024, 1, 1), unix(2024, 6, 1), 0},
+		{"not yet born", unix(2025, 1, 1), unix(2024, 6, 1), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AgeYears(tt.birth, tt.now); got != tt.expected {
+				t.Errorf("AgeYears(%d, %d) = %d, want %d", tt.birth, tt.now, got, tt.expected)
+			}
+		})
+	}
+
+	// Born 1990-03-05 01:00 in +09:00, which is still March 4 in UTC.
+	jst := time.FixedZone("JST", 9*3600)
+	birth := time.Date(1990, 3, 5, 1, 0, 0, 0, jst).Unix()
+	now := time.Date(2024, 3, 4, 23, 0, 0, 0, jst).Unix()
+	if got := AgeYearsIn(birth, now, jst); got != 33 {
+		t.Errorf("AgeYearsIn JST = %d, want 33", got)
+	}
+	if got := AgeYears(birth, now); got != 34 {
+		t.Errorf("AgeYears UTC = %d, want 34", got)
+	}
+}
+
+func TestNames(t *testing.T) {
+	ref := int64(1705276800) // 2024-01-15 Monday 00:00 UTC
+	abbrev := DateOptions{Names: &EnglishAbbrevNames}
+	en := English()
+
+	tests := []struct {
+		name     string
+		got      string
+		expected string
+	}{
+		{"month", HumanDateWith(1709596800, ref, abbrev), "Mar 5"},
+		{"month and year", HumanDateWith(1672531200, ref, abbrev), "Jan 1, 2023"},
+		{"weekday", HumanDateWith(1705017600, ref, abbrev), "Last Fri"},
+		{"ordinal", HumanDateWith(1709596800, ref, DateOptions{Names: &EnglishAbbrevNames, Ordinal: true}), "Mar 5th"},
+		{"range", DateRangeWith(1705276800, 1707955200, abbrev), "Jan 15 – Feb 15, 2024"},
+		{"default", HumanDateWith(1709596800, ref, DateOptions{}), "March 5"},
+		{"locale", HumanDateWith(1709596800, ref, DateOptions{Names: &en.Names}), "March 5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.expected {
+				t.Errorf("got %q, want %q", tt.got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWeekOfYear(t *testing.T) {
+	unix := func(y int, m time.Month, d int) int64 {
+		return time.Date(y, m, d, 12, 0, 0, 0, time.UTC).Unix()
+	}
+
+	tests := []struct {
+		timestamp int64
+		year      int
+		week      int
+		expected  string
+	}{
+		{unix(2024, 3, 5), 2024, 10, "2024-W10"},
+		{unix(2024, 1, 1), 2024, 1, "2024-W01"},   // Monday
+		{unix(2023, 1, 1), 2022, 52, "2022-W52"},  // Sunday belongs to the previous ISO year
+		{unix(2021, 1, 3), 2020, 53, "2020-W53"},  // 2020 has 53 ISO weeks
+		{unix(2024, 12, 30), 2025, 1, "2025-W01"}, // Monday of the next ISO year
+		{unix(2024, 12, 29), 2024, 52, "2024-W52"},
+		{unix(2026, 12, 31), 2026, 53, "2026-W53"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			year, week := WeekOfYear(tt.timestamp)
+			if year != tt.year || week != tt.week {
+				t.Errorf("WeekOfYear(%d) = (%d, %d), want (%d, %d)", tt.timestamp, year, week, tt.year, tt.week)
+			}
+			if got := FormatWeek(tt.timestamp); got != tt.expected {
+				t.Errorf("FormatWeek(%d) = %q, want %q", tt.timestamp, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDurationWith(t *testing.T) {
+	tests := []struct {
+		seconds  int
+		opts     DurationOptions
+		expected string
+	}{
+		{7020, DurationOptions{Fuzzy: true}, "about 2 hours"},
+		{7200, DurationOptions{Fuzzy: true}, "2 hours"},
+		{30, DurationOptions{Fuzzy: true}, "30 seconds"},
+		{90, DurationOptions{Fuzzy: true}, "about 2 minutes"},
+		{3590, DurationOptions{Fuzzy: true}, "about 1 hour"},
+		{4000, DurationOptions{Fuzzy: true, Compact: true}, "about 1h"},
+		{0, DurationOptions{Fuzzy: true}, "0 seconds"},
+		{9000, DurationOptions{}, "2 hours, 30 minutes"},
+		{9045, DurationOptions{Compact: true, MaxUnits: 3}, "2h 30m 45s"},
+		{3661, DurationOptions{MaxUnits: 1, RoundMode: Floor}, "1 hour"},
+		{3661, DurationOptions{MaxUnits: 1, RoundMode: Ceil}, "2 hours"},
+		{3661, DurationOptions{MaxUnits: 1, RoundMode: Nearest}, "1 hour"},
+		{5400, DurationOptions{MaxUnits: 1, RoundMode: Floor}, "1 hour"},
+		{3600, DurationOptions{MaxUnits: 1, RoundMode: Ceil}, "1 hour"},
+		{3661, DurationOptions{Compact: true, RoundMode: Ceil}, "1h 2m"},
+		{3601, DurationOptions{Fuzzy: true, RoundMode: Ceil}, "about 2 hours"},
+		{5400, DurationOptions{FinalConjunction: "and"}, "1 hour and 30 minutes"},
+		{95400, DurationOptions{MaxUnits: 3, FinalConjunction: "and"}, "1 day, 2 hours and 30 minutes"},
+		{3600, DurationOptions{FinalConjunction: "and"}, "1 hour"},
+		{9045, DurationOptions{Compact: true, MaxUnits: 3, Separator: "/"}, "2h/30m/45s"},
+		{9045, DurationOptions{MaxUnits: 3, Separator: " ", FinalConjunction: "&"}, "2 hours 30 minutes & 45 seconds"},
+		{31719845, DurationOptions{AllUnits: true}, "1 year, 2 days, 3 hours, 4 minutes, 5 seconds"},
+		{31719845, DurationOptions{AllUnits: true, MaxUnits: 1, Compact: true}, "1y 2d 3h 4m 5s"},
+		{31719845, DurationOptions{}, "1 year, 2 days"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			got := DurationWith(tt.seconds, tt.opts)
+			if got != tt.expected {
+				t.Errorf("DurationWith(%d, %+v) = %q, want %q", tt.seconds, tt.opts, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTimeAgoMaxUnit(t *testing.T) {
+	ref := int64(1704067200) // 2024-01-01 00:00:00 UTC
+	day := int64(86400)
+
+	tests := []struct {
+		timestamp int64
+		opts      TimeAgoOptions
+		expected  string
+	}{
+		{ref - 400*day, TimeAgoOptions{MaxUnit: "day"}, "400 days ago"},
+		{ref - 3*365*day, TimeAgoOptions{MaxUnit: "day"}, "1095 days ago"},
+		{ref - 3*day, TimeAgoOptions{MaxUnit: "hour"}, "72 hours ago"},
+		{ref + 3*day + 1800, TimeAgoOptions{MaxUnit: "hour"}, "in 73 hours"},
+		{ref - 5*3600, TimeAgoOptions{MaxUnit: "day"}, "5 hours ago"},
+		{ref - 90*day, TimeAgoOptions{MaxUnit: "week"}, "13 weeks ago"},
+		{ref - 400*day, TimeAgoOptions{}, "1 year ago"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			got := TimeAgoWith(tt.timestamp, ref, tt.opts)
+			if got != tt.expected {
+				t.Errorf("TimeAgoWith(%d, %d, %+v) = %q, want %q", tt.timestamp, ref, tt.opts, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestUseArticles(t *testing.T) {
+	ref := int64(1704067200) // 2024-01-01 00:00:00 UTC
+	articles := TimeAgoOptions{UseArticles: true}
+
+	timeAgoTests := []struct {
+		timestamp int64
+		expected  string
+	}{
+		{ref - 3600, "an hour ago"},
+		{ref - 60, "a minute ago"},
+		{ref - 31536000, "a year ago"},
+		{ref + 86400, "in a day"},
+		{ref - 7200, "2 hours ago"},
+		{ref - 10, "just now"},
+	}
+	for _, tt := range timeAgoTests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if got := TimeAgoWith(tt.timestamp, ref, articles); got != tt.expected {
+				t.Errorf("TimeAgoWith(%d, %d, %+v) = %q, want %q", tt.timestamp, ref, articles, got, tt.expected)
+			}
+		})
+	}
+
+	durationTests := []struct {
+		seconds  int
+		opts     DurationOptions
+		expected string
+	}{
+		{5400, DurationOptions{UseArticles: true}, "an hour, 30 minutes"},
+		{3660, DurationOptions{UseArticles: true}, "an hour, a minute"},
+		{3590, DurationOptions{UseArticles: true, Fuzzy: true}, "about an hour"},
+		{3600, DurationOptions{UseArticles: true, Compact: true}, "1h"},
+		{3600, DurationOptions{}, "1 hour"},
+	}
+	for _, tt := range durationTests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if got := DurationWith(tt.seconds, tt.opts); got != tt.expected {
+				t.Errorf("DurationWith(%d, %+v) = %q, want %q", tt.seconds, tt.opts, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDateRangeIn(t *testing.T) {
+	pst := time.FixedZone("PST", -8*3600)
+	// 2024-03-05 18:00 to 23:00 PST is 2024-03-06 02:00 to 07:00 UTC...
+	sameDayPST := [2]int64{1709690400, 1709708400}
+	// ...while 2024-03-05 20:00 to 2024-03-06 02:00 UTC spans midnight UTC
+	// but stays on March 5 in PST.
+	spansUTC := [2]int64{1709668800, 1709690400}
+
+	tests := []struct {
+		name     string
+		rng      [2]int64
+		loc      *time.Location
+		expected string
+	}{
+		{"pst same day", sameDayPST, pst, "March 5, 2024"},
+		{"utc same day", sameDayPST, time.UTC, "March 6, 2024"},
+		{"pst collapses", spansUTC, pst, "March 5, 2024"},
+		{"utc splits", spansUTC, time.UTC, "March 5–6, 2024"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DateRangeIn(tt.rng[0], tt.rng[1], tt.loc); got != tt.expected {
+				t.Errorf("DateRangeIn(%d, %d, %s) = %q, want %q", tt.rng[0], tt.rng[1], tt.loc, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSignedDuration(t *testing.T) {
+	tests := []struct {
+		seconds  int
+		compact  bool
+		expected string
+	}{
+		{-9000, true, "-2h 30m"},
+		{-9000, false, "-2 hours, 30 minutes"},
+		{-1, false, "-1 second"},
+		{9000, true, "2h 30m"},
+		{0, true, "0s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if got := SignedDuration(tt.seconds, tt.compact, 2); got != tt.expected {
+				t.Errorf("SignedDuration(%d, %v, 2) = %q, want %q", tt.seconds, tt.compact, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseDurationStrict(t *testing.T) {
+	valid := []struct {
+		input    string
+		expected int
+	}{
+		{"2h30m", 9000},
+		{"1d 2h 30m", 95400},
+		{"1 day, 2 hours, and 30 minutes", 95400},
+		{"2 hours and 30 minutes", 9000},
+		{"1:30:00", 5400},
+		{"PT2H30M", 9000},
+		{"an hour and a half", 5400},
+	}
+	for _, tt := range valid {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseDurationStrict(tt.input)
+			if err != nil {
+				t.Fatalf("ParseDurationStrict(%q) returned error: %v", tt.input, err)
+			}
+			if got != tt.expected {
+				t.Errorf("ParseDurationStrict(%q) = %d, want %d", tt.input, got, tt.expected)
+			}
+		})
+	}
+
+	invalid := []struct {
+		input string
+		err   error
+	}{
+		{"2h 3h", errDuplicateUnit},
+		{"2 hours 3 hrs", errDuplicateUnit},
+		{"30m 2h", errUnitOrder},
+		{"2h 30m and", errUnrecognized},
+		{"2h, , 30m", errUnrecognized},
+		{"5 foos", errUnknownUnit},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.input, func(t *testing.T) {
+			if _, err := ParseDurationStrict(tt.input); err != tt.err {
+				t.Errorf("ParseDurationStrict(%q) error = %v, want %v", tt.input, err, tt.err)
+			}
+		})
+	}
+
+	// The lenient parser still accepts what strict mode rejects.
+	if got, err := ParseDuration("30m 2h"); err != nil || got != 9000 {
+		t.Errorf("ParseDuration(%q) = %d, %v; want 9000, nil", "30m 2h", got, err)
+	}
+}
+
+func TestClock24(t *testing.T) {
+	ref := int64(1705276800) // 2024-01-15 Monday 00:00 UTC
+	h12 := DateOptions{}
+	h24 := DateOptions{Clock24: true}
+
+	tests := []struct {
+		name     string
+		got      string
+		expected string
+	}{
+		{"midnight 12h", HumanDateTime(ref, ref, h12), "Today at 12:00 AM"},
+		{"midnight 24h", HumanDateTime(ref, ref, h24), "Today at 00:00"},
+		{"noon 12h", HumanDateTime(ref+43200, ref, h12), "Today at 12:00 PM"},
+		{"noon 24h", HumanDateTime(ref+43200, ref, h24), "Today at 12:00"},
+		{"afternoon 12h", HumanDateTime(ref+54300, ref, h12), "Today at 3:05 PM"},
+		{"afternoon 24h", HumanDateTime(ref+54300, ref, h24), "Today at 15:05"},
+		{"range 12h", DateTimeRangeWith(ref, ref+54300, h12), "January 15, 2024, 12:00 AM – 3:05 PM"},
+		{"range 24h", DateTimeRangeWith(ref, ref+54300, h24), "January 15, 2024, 00:00 – 15:05"},
+		{"range noon 24h", DateTimeRangeWith(ref+43200, ref+86400+43200, h24), "January 15, 12:00 – January 16, 2024, 12:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.expected {
+				t.Errorf("got %q, want %q", tt.got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsWeekend(t *testing.T) {
+	mon := int64(1705276800) // 2024-01-15 Monday 00:00 UTC
+	day := int64(86400)
+	pst := time.FixedZone("PST", -8*3600)
+
+	tests := []struct {
+		name     string
+		got      bool
+		expected bool
+	}{
+		{"saturday", IsWeekend(mon+5*day, time.UTC), true},
+		{"sunday", IsWeekend(mon+6*day, time.UTC), true},
+		{"wednesday", IsWeekend(mon+2*day, time.UTC), false},
+		{"monday utc is sunday pst", IsWeekend(mon+3600, pst), true},
+		{"custom friday", FridaySaturday.Includes(mon+4*day, time.UTC), true},
+		{"custom saturday", FridaySaturday.Includes(mon+5*day, time.UTC), true},
+		{"custom sunday", FridaySaturday.Includes(mon+6*day, time.UTC), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.expected {
+				t.Errorf("got %v, want %v", tt.got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestShortWeekdays(t *testing.T) {
+	ref := int64(1705276800) // 2024-01-15 Monday 00:00 UTC
+	fr, _ := LookupLocale("fr")
+	custom := Names{Weekdays: EnglishNames().Weekdays, Months: EnglishNames().Months}
+
+	tests := []struct {
+		name     string
+		opts     DateOptions
+		ts       int64
+		expected string
+	}{
+		{"last saturday", DateOptions{ShortWeekdays: true}, 1705104000, "Last Sat"},
+		{"this wednesday", DateOptions{ShortWeekdays: true}, 1705449600, "This Wed"},
+		{"full by default", DateOptions{}, 1705104000, "Last Saturday"},
+		{"localized", DateOptions{ShortWeekdays: true, Names: &fr.Names}, 1705104000, "Last sam."},
+		{"no abbreviations", DateOptions{ShortWeekdays: true, Names: &custom}, 1705104000, "Last Saturday"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HumanDateWith(tt.ts, ref, tt.opts); got != tt.expected {
+				t.Errorf("HumanDateWith(%d, %d, %+v) = %q, want %q", tt.ts, ref, tt.opts, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRelativeFuture(t *testing.T) {
+	ref := int64(1705276800) // 2024-01-15 Monday 00:00 UTC
+	day := int64(86400)
+	rel := DateOptions{RelativeFuture: true}
+
+	tests := []struct {
+		ts       int64
+		opts     DateOptions
+		expected string
+	}{
+		{ref + 6*day, rel, "This Sunday"},
+		{ref + 7*day, rel, "In 1 week"},
+		{ref + 10*day, rel, "In 1 week"},
+		{ref + 14*day, rel, "In 2 weeks"},
+		{ref + 27*day, rel, "In 4 weeks"},
+		{ref + 30*day, rel, "Next month"},
+		{ref + 60*day, rel, "In 2 months"},
+		{ref + 400*day, rel, "Next year"},
+		{ref + 800*day, rel, "In 2 years"},
+		{ref - 30*day, rel, "December 16, 2023"},
+		{ref + 60*day, DateOptions{}, "March 15"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if got := HumanDateWith(tt.ts, ref, tt.opts); got != tt.expected {
+				t.Errorf("HumanDateWith(%d, %d, %+v) = %q, want %q", tt.ts, ref, tt.opts, got, tt.expected)
+			}
+		})
+	}
+
+	if got := HumanDateTime(ref+14*day+32400, ref, rel); got != "In 2 weeks at 9:00 AM" {
+		t.Errorf("HumanDateTime = %q, want %q", got, "In 2 weeks at 9:00 AM")
+	}
+}
+
+func TestEarlierLaterToday(t *testing.T) {
+	day := int64(1705276800) // 2024-01-15 Monday 00:00 UTC
+	morning, afternoon := day+9*3600, day+15*3600
+	opts := DateOptions{EarlierLater: true}
+
+	tests := []struct {
+		name     string
+		got      string
+		expected string
+	}{
+		{"morning seen in afternoon", HumanDateWith(morning, afternoon, opts), "Earlier today"},
+		{"afternoon seen in morning", HumanDateWith(afternoon, morning, opts), "Later today"},
+		{"essentially now", HumanDateWith(afternoon+30, afternoon, opts), "Today"},
+		{"44s before", HumanDateWith(afternoon-44, afternoon, opts), "Today"},
+		{"45s before", HumanDateWith(afternoon-45, afternoon, opts), "Earlier today"},
+		{"44s after", HumanDateWith(afternoon+44, afternoon, opts), "Today"},
+		{"45s after", HumanDateWith(afternoon+45, afternoon, opts), "Later today"},
+		{"yesterday unaffected", HumanDateWith(morning-86400, afternoon, opts), "Yesterday"},
+		{"off by default", HumanDateWith(morning, afternoon, DateOptions{}), "Today"},
+		{"with time", HumanDateTime(morning, afternoon, opts), "Earlier today at 9:00 AM"},
+		{"with 24h time", HumanDateTime(afternoon, morning, DateOptions{EarlierLater: true, Clock24: true}), "Later today at 15:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.expected {
+				t.Errorf("got %q, want %q", tt.got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTimeAgoParts(t *testing.T) {
+	ref := int64(1704067200) // 2024-01-01 00:00:00 UTC
+
+	tests := []struct {
+		timestamp int64
+		value     int
+		unit      string
+		future    bool
+		justNow   bool
+	}{
+		{ref - 10, 0, "", false, true},
+		{ref + 10, 0, "", true, true},
+		{ref - 60, 1, "minute", false, false},
+		{ref - 1800, 30, "minute", false, false},
+		{ref + 18000, 5, "hour", true, false},
+		{ref - 172800, 2, "day", false, false},
+		{ref + 15552000, 6, "month", true, false},
+		{ref - 157680000, 5, "year", false, false},
+	}
+
+	for _, tt := range tests {
+		value, unit, future, justNow := TimeAgoParts(tt.timestamp, ref)
+		if value != tt.value || unit != tt.unit || future != tt.future || justNow != tt.justNow {
+			t.Errorf("TimeAgoParts(%d, %d) = (%d, %q, %v, %v), want (%d, %q, %v, %v)",
+				tt.timestamp, ref, value, unit, future, justNow, tt.value, tt.unit, tt.future, tt.justNow)
+		}
+	}
+}
+
+func TestDateRangeRelative(t *testing.T) {
+	mon := int64(1705276800) // 2024-01-15 Monday 00:00 UTC
+	day := int64(86400)
+	now := mon + 2*day + 3600 // Wednesday
+
+	tests := []struct {
+		name     string
+		start    int64
+		end      int64
+		expected string
+	}{
+		{"inside current week", mon + day, mon + 4*day, "This week"},
+		{"whole current week", mon, mon + 6*day + 82800, "This week"},
+		{"inside previous week", mon - 6*day, mon - 2*day, "Last week"},
+		{"reversed previous week", mon - 2*day, mon - 6*day, "Last week"},
+		{"spans both weeks", mon - 2*day, mon + day, "January 13–16, 2024"},
+		{"two weeks ago", mon - 13*day, mon - 9*day, "January 2–6, 2024"},
+		{"next week", mon + 7*day, mon + 8*day, "January 22–23, 2024"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DateRangeRelative(tt.start, tt.end, now); got != tt.expected {
+				t.Errorf("DateRangeRelative(%d, %d, %d) = %q, want %q", tt.start, tt.end, now, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatClock(t *testing.T) {
+	day := int64(1705276800) // 2024-01-15 00:00 UTC
+	pst := time.FixedZone("PST", -8*3600)
+
+	tests := []struct {
+		timestamp int64
+		loc       *time.Location
+		clock24   bool
+		expected  string
+	}{
+		{day, time.UTC, false, "12:00 AM"},
+		{day, time.UTC, true, "00:00"},
+		{day + 43200, time.UTC, false, "12:00 PM"},
+		{day + 43200, time.UTC, true, "12:00"},
+		{day + 54300, time.UTC, false, "3:05 PM"},
+		{day + 54300, time.UTC, true, "15:05"},
+		{day + 32460, time.UTC, true, "09:01"},
+		{day + 54300, pst, false, "7:05 AM"},
+		{day, pst, true, "16:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if got := FormatClock(tt.timestamp, tt.loc, tt.clock24); got != tt.expected {
+				t.Errorf("FormatClock(%d, %s, %v) = %q, want %q", tt.timestamp, tt.loc, tt.clock24, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTimeAgoWithThresholds(t *testing.T) {
+	ref := int64(1704067200) // 2024-01-01 00:00:00 UTC
+
+	// Keep counting minutes up to 59 before switching to hours.
+	custom := DefaultThresholds()
+	custom[2] = Threshold{Max: 3569, Unit: "minute"}
+	custom[3] = Threshold{Max: 5340, Unit: "hour", Value: 1}
+
+	tests := []struct {
+		timestamp  int64
+		thresholds Thresholds
+		expected   string
+	}{
+		{ref - 3000, DefaultThresholds(), "1 hour ago"},
+		{ref - 3000, custom, "50 minutes ago"},
+		{ref - 3540, custom, "59 minutes ago"},
+		{ref - 3600, custom, "1 hour ago"},
+		{ref + 3000, custom, "in 50 minutes"},
+		{ref - 7200, custom, "2 hours ago"},
+		{ref - 3000, nil, "1 hour ago"},
+		{ref - 20*86400, Thresholds{{44, "", 0}, {math.MaxInt64, "fortnight", 0}}, "20 days ago"},
+		{ref - 3000, Thresholds{{math.MaxInt64, "second", 0}}, "1 hour ago"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if got := TimeAgoWithThresholds(tt.timestamp, ref, tt.thresholds); got != tt.expected {
+				t.Errorf("TimeAgoWithThresholds(%d, %d) = %q, want %q", tt.timestamp, ref, got, tt.expected)
+			}
+		})
+	}
+
+	// DefaultThresholds returns a copy, so editing it leaves TimeAgo alone.
+	mutated := DefaultThresholds()
+	mutated[2].Max = 10
+	if got := TimeAgo(ref-3000, ref); got != "1 hour ago" {
+		t.Errorf("TimeAgo after editing a DefaultThresholds copy = %q, want %q", got, "1 hour ago")
+	}
+
+	// UseWeeks starts where the active table stops showing "1 day".
+	longDay := Thresholds{{44, "", 0}, {864000, "day", 1}, {2160000, "day", 0}, {math.MaxInt64, "month", 0}}
+	weeks := TimeAgoOptions{UseWeeks: true, Thresholds: longDay}
+	if got := TimeAgoWith(ref-8*86400, ref, weeks); got != "1 day ago" {
+		t.Errorf("TimeAgoWith(8 days, long day tier) = %q, want %q", got, "1 day ago")
+	}
+	if got := TimeAgoWith(ref-14*86400, ref, weeks); got != "2 weeks ago" {
+		t.Errorf("TimeAgoWith(14 days, long day tier) = %q, want %q", got, "2 weeks ago")
+	}
+}
+
+func TestDurationParts(t *testing.T) {
+	tests := []struct {
+		seconds  int
+		expected []DurationComponent
+	}{
+		{90061, []DurationComponent{{"day", 1}, {"hour", 1}, {"minute", 1}, {"second", 1}}},
+		{3600, []DurationComponent{{"hour", 1}}},
+		{34218061, []DurationComponent{{"year", 1}, {"month", 1}, {"day", 1}, {"hour", 1}, {"minute", 1}, {"second", 1}}},
+		{0, nil},
+	}
+
+	for _, tt := range tests {
+		got := DurationParts(tt.seconds)
+		if len(got) != len(tt.expected) {
+			t.Errorf("DurationParts(%d) = %v, want %v", tt.seconds, got, tt.expected)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.expected[i] {
+				t.Errorf("DurationParts(%d) = %v, want %v", tt.seconds, got, tt.expected)
+				break
+			}
+		}
+	}
+}
+
+func TestPluralize(t *testing.T) {
+	// Polish-style forms: one, few (2–4, except 12–14), many.
+	forms := map[string][3]string{
+		"second": {"sekunda", "sekundy", "sekund"},
+		"minute": {"minuta", "minuty", "minut"},
+		"hour":   {"godzina", "godziny", "godzin"},
+		"day":    {"dzień", "dni", "dni"},
+	}
+	pluralize := func(unit string, n int) string {
+		f := forms[unit]
+		switch {
+		case n == 1:
+			return f[0]
+		case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+			return f[1]
+		default:
+			return f[2]
+		}
+	}
+
+	ref := int64(1704067200) // 2024-01-01 00:00:00 UTC
+	opts := TimeAgoOptions{Pluralize: pluralize}
+	timeAgoTests := []struct {
+		timestamp int64
+		expected  string
+	}{
+		{ref - 3600, "1 godzina ago"},
+		{ref - 3*3600, "3 godziny ago"},
+		{ref - 5*3600, "5 godzin ago"},
+		{ref - 12*3600, "12 godzin ago"},
+		{ref + 22*3600, "in 1 dzień"},
+		{ref + 22*60, "in 22 minuty"},
+	}
+	for _, tt := range timeAgoTests {
+		if got := TimeAgoWith(tt.timestamp, ref, opts); got != tt.expected {
+			t.Errorf("TimeAgoWith(%d, %d) = %q, want %q", tt.timestamp, ref, got, tt.expected)
+		}
+	}
+
+	pl := English()
+	pl.JustNow = "przed chwilą"
+	pl.Past = "%s temu"
+	pl.Future = "za %s"
+	pl.Pluralize = pluralize
+	if got := TimeAgoLocale(ref-2*3600, ref, pl); got != "2 godziny temu" {
+		t.Errorf("TimeAgoLocale = %q, want %q", got, "2 godziny temu")
+	}
+
+	durationTests := []struct {
+		seconds  int
+		expected string
+	}{
+		{9000, "2 godziny, 30 minut"},
+		{3660, "1 godzina, 1 minuta"},
+		{18245, "5 godzin, 4 minuty"},
+		{0, "0 sekund"},
+	}
+	for _, tt := range durationTests {
+		if got := DurationWith(tt.seconds, DurationOptions{Pluralize: pluralize}); got != tt.expected {
+			t.Errorf("DurationWith(%d) = %q, want %q", tt.seconds, got, tt.expected)
+		}
+	}
+}
+
+func TestParseDurationMonths(t *testing.T) {
+	jan1 := int64(1704067200) // 2024-01-01 00:00 UTC
+	feb1 := int64(1706745600) // 2024-02-01 00:00 UTC
+	mar1 := int64(1709251200) // 2024-03-01 00:00 UTC
+
+	tests := []struct {
+		name     string
+		input    string
+		opts     ParseOptions
+		expected int
+	}{
+		{"30-day default", "2 months", ParseOptions{}, 5184000},
+		{"average", "2 months", ParseOptions{Months: MonthAverage}, 5259492},
+		{"average iso", "P1M", ParseOptions{Months: MonthAverage}, 2629746},
+		{"average year", "1y", ParseOptions{Months: MonthAverage}, 31556952},
+		{"average decade", "1 decade", ParseOptions{Months: MonthAverage}, 315569520},
+		{"calendar march", "2 months", ParseOptions{Months: MonthCalendar, Reference: mar1}, 5270400},
+		{"calendar leap february", "1 month 2 days", ParseOptions{Months: MonthCalendar, Reference: feb1}, 2678400},
+		{"calendar leap year", "1 year", ParseOptions{Months: MonthCalendar, Reference: jan1}, 31622400},
+		{"calendar fraction", "1.5mo", ParseOptions{Months: MonthCalendar, Reference: jan1}, 2678400 + 1296000},
+		{"no months", "2h30m", ParseOptions{Months: MonthAverage}, 9000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDurationWith(tt.input, tt.opts)
+			if err != nil {
+				t.Fatalf("ParseDurationWith(%q) returned error: %v", tt.input, err)
+			}
+			if got != tt.expected {
+				t.Errorf("ParseDurationWith(%q, %+v) = %d, want %d", tt.input, tt.opts, got, tt.expected)
+			}
+		})
+	}
+
+	fromTests := []struct {
+		input    string
+		ref      int64
+		expected int
+	}{
+		{"1 month", feb1, 29 * 86400},
+		{"1 month", 1706659200, 29 * 86400},    // Jan 31, 2024 to Feb 29
+		{"1 month", 1675123200, 28 * 86400},    // Jan 31, 2023 to Feb 28
+		{"13 months", 1675123200, 394 * 86400}, // Jan 31, 2023 to Feb 29, 2024
+	}
+	for _, tt := range fromTests {
+		if got, err := ParseDurationFrom(tt.input, tt.ref); err != nil || got != tt.expected {
+			t.Errorf("ParseDurationFrom(%q, %d) = %d, %v; want %d, nil", tt.input, tt.ref, got, err, tt.expected)
+		}
+	}
+	if _, err := ParseDurationWith("30m 2h", ParseOptions{Strict: true}); err != errUnitOrder {
+		t.Errorf("ParseDurationWith strict error = %v, want %v", err, errUnitOrder)
+	}
+}
+
+func TestRangeSeparator(t *testing.T) {
+	tests := []struct {
+		name     string
+		start    int64
+		end      int64
+		opts     DateOptions
+		expected string
+	}{
+		{"hyphen same month", 1705276800, 1705881600, DateOptions{RangeSeparator: "-"}, "January 15-22, 2024"},
+		{"to same month", 1705276800, 1705881600, DateOptions{RangeSeparator: " to "}, "January 15 to 22, 2024"},
+		{"to bare", 1705276800, 1705881600, DateOptions{RangeSeparator: "to"}, "January 15 to 22, 2024"},
+		{"hyphen same year", 1705276800, 1707955200, DateOptions{RangeSeparator: "-"}, "January 15 - February 15, 2024"},
+		{"to different years", 1703721600, 1705276800, DateOptions{RangeSeparator: "to"}, "December 28, 2023 to January 15, 2024"},
+		{"default", 1705276800, 1705881600, DateOptions{}, "January 15–22, 2024"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DateRangeWith(tt.start, tt.end, tt.opts); got != tt.expected {
+				t.Errorf("DateRangeWith(%d, %d, %+v) = %q, want %q", tt.start, tt.end, tt.opts, got, tt.expected)
+			}
+		})
+	}
+
+	day := int64(1709596800) // 2024-03-05 00:00 UTC
+	if got := DateTimeRangeWith(day+32400, day+64800, DateOptions{RangeSeparator: "-"}); got != "March 5, 2024, 9:00 AM - 6:00 PM" {
+		t.Errorf("DateTimeRangeWith = %q, want %q", got, "March 5, 2024, 9:00 AM - 6:00 PM")
+	}
+}
+
+func TestTimeAgoDayWords(t *testing.T) {
+	ref := int64(1704067200) // 2024-01-01 00:00:00 UTC
+	day := int64(86400)
+	opts := TimeAgoOptions{DayWords: true}
+
+	tests := []struct {
+		timestamp int64
+		expected  string
+	}{
+		{ref - day, "yesterday"},
+		{ref + day, "tomorrow"},
+		{ref - 30*3600, "yesterday"},
+		{ref - 2*day, "2 days ago"},
+		{ref + 2*day, "in 2 days"},
+		{ref - 5*3600, "5 hours ago"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if got := TimeAgoWith(tt.timestamp, ref, opts); got != tt.expected {
+				t.Errorf("TimeAgoWith(%d, %d, %+v) = %q, want %q", tt.timestamp, ref, opts, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTimeAgoCompact(t *testing.T) {
+	ref := int64(1704067200) // 2024-01-01 00:00:00 UTC
+
+	tests := []struct {
+		timestamp int64
+		expected  string
+	}{
+		{ref - 10, "now"},
+		{ref + 10, "now"},
+		{ref - 300, "-5m"},
+		{ref + 300, "+5m"},
+		{ref - 3*3600, "-3h"},
+		{ref + 2*86400, "+2d"},
+		{ref - 15552000, "-6mo"},
+		{ref + 157680000, "+5y"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if got := TimeAgoCompact(tt.timestamp, ref); got != tt.expected {
+				t.Errorf("TimeAgoCompact(%d, %d) = %q, want %q", tt.timestamp, ref, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNanos(t *testing.T) {
+	ref := int64(1704067200) * int64(time.Second) // 2024-01-01 00:00:00 UTC
+
+	tests := []struct {
+		name     string
+		got      string
+		expected string
+	}{
+		{"sub-second past", TimeAgoNanos(ref-int64(500*time.Millisecond), ref), "just now"},
+		{"sub-second future", TimeAgoNanos(ref+1, ref), "just now"},
+		{"just under 45s", TimeAgoNanos(ref-int64(44999*time.Millisecond), ref), "just now"},
+		{"hours", TimeAgoNanos(ref-int64(3*time.Hour)-123456789, ref), "3 hours ago"},
+		{"future days", TimeAgoNanos(ref+int64(48*time.Hour), ref), "in 2 days"},
+		{"date", HumanDateNanos(ref-int64(24*time.Hour)+1, ref), "Yesterday"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.expected {
+				t.Errorf("got %q, want %q", tt.got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDurationBetween(t *testing.T) {
+	start := int64(1704067200) // 2024-01-01 00:00:00 UTC
+
+	tests := []struct {
+		start    int64
+		end      int64
+		compact  bool
+		expected string
+	}{
+		{start, start + 9000, true, "2h 30m"},
+		{start + 9000, start, true, "2h 30m"},
+		{start, start + 95400, false, "1 day, 3 hours"},
+		{start, start, false, "0 seconds"},
+		{start, start, true, "0s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if got := DurationBetween(tt.start, tt.end, tt.compact, 2); got != tt.expected {
+				t.Errorf("DurationBetween(%d, %d, %v, 2) = %q, want %q", tt.start, tt.end, tt.compact, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseTimestamp(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"2024-01-01T00:00:00Z", 1704067200},
+		{"2024-01-15T08:00:00-08:00", 1705334400},
+		{"2024-01-01T00:00:00.750Z", 1704067200},
+		{"2024-03-05", 1709596800},
+		{" 2024-03-05 ", 1709596800},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseTimestamp(tt.input)
+			if err != nil {
+				t.Fatalf("ParseTimestamp(%q) returned error: %v", tt.input, err)
+			}
+			if got != tt.expected {
+				t.Errorf("ParseTimestamp(%q) = %d, want %d", tt.input, got, tt.expected)
+			}
+		})
+	}
+
+	for _, input := range []string{"", "yesterday", "2024-13-01", "2024-03-05 14:30", "1704067200"} {
+		if _, err := ParseTimestamp(input); err == nil {
+			t.Errorf("ParseTimestamp(%q) should have returned error", input)
+		}
+	}
+}
+
+func TestWeekStart(t *testing.T) {
+	wed := int64(1705449600) // 2024-01-17 Wednesday 00:00 UTC
+	day := int64(86400)
+	sunday := DateOptions{CalendarWeeks: true, WeekStart: time.Sunday}
+	monday := DateOptions{CalendarWeeks: true, WeekStart: time.Monday}
+
+	tests := []struct {
+		name     string
+		ts       int64
+		opts     DateOptions
+		expected string
+	}{
+		{"sunday before, sunday start", wed - 3*day, sunday, "This Sunday"},
+		{"sunday before, monday start", wed - 3*day, monday, "Last Sunday"},
+		{"sunday after, sunday start", wed + 4*day, sunday, "Next Sunday"},
+		{"sunday after, monday start", wed + 4*day, monday, "This Sunday"},
+		{"friday ahead, monday start", wed + 2*day, monday, "This Friday"},
+		{"friday ahead, sunday start", wed + 2*day, sunday, "This Friday"},
+		{"monday before, monday start", wed - 2*day, monday, "This Monday"},
+		{"thursday before", wed - 6*day, monday, "Last Thursday"},
+		{"tuesday after", wed + 6*day, monday, "Next Tuesday"},
+		{"yesterday unchanged", wed - day, monday, "Yesterday"},
+		{"default window", wed - 3*day, DateOptions{}, "Last Sunday"},
+		{"default window ahead", wed + 4*day, DateOptions{}, "This Sunday"},
+		{"week start alone, before", wed - 3*day, DateOptions{WeekStart: time.Monday}, "Last Sunday"},
+		{"week start alone, ahead", wed + 4*day, DateOptions{WeekStart: time.Monday}, "This Sunday"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HumanDateWith(tt.ts, wed, tt.opts); got != tt.expected {
+				t.Errorf("HumanDateWith(%d, %d, %+v) = %q, want %q", tt.ts, wed, tt.opts, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestJustNowText(t *testing.T) {
+	ref := int64(1704067200) // 2024-01-01 00:00:00 UTC
+	moments := TimeAgoOptions{JustNowText: "moments ago", SoonText: "in a moment"}
+
+	tests := []struct {
+		timestamp int64
+		opts      TimeAgoOptions
+		expected  string
+	}{
+		{ref - 30, moments, "moments ago"},
+		{ref, moments, "moments ago"},
+		{ref + 30, moments, "in a moment"},
+		{ref - 60, moments, "1 minute ago"},
+		{ref + 60, moments, "in 1 minute"},
+		{ref + 30, TimeAgoOptions{JustNowText: "moments ago"}, "just now"},
+		{ref - 30, TimeAgoOptions{}, "just now"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if got := TimeAgoWith(tt.timestamp, ref, tt.opts); got != tt.expected {
+				t.Errorf("TimeAgoWith(%d, %d, %+v) = %q, want %q", tt.timestamp, ref, tt.opts, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNormalizeDuration(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"90 minutes", "1h 30m"},
+		{"1.5 hours", "1h 30m"},
+		{"1:30", "1h 30m"},
+		{"PT1H30M", "1h 30m"},
+		{"an hour and a half", "1h 30m"},
+		{"1 day, 2 hours, and 30 minutes 5 seconds", "1d 2h 30m 5s"},
+		{"0s", "0s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := NormalizeDuration(tt.input)
+			if err != nil {
+				t.Fatalf("NormalizeDuration(%q) returned error: %v", tt.input, err)
+			}
+			if got != tt.expected {
+				t.Errorf("NormalizeDuration(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+
+	if _, err := NormalizeDuration("5 foos"); err == nil {
+		t.Error("NormalizeDuration(\"5 foos\") should have returned error")
+	}
+}
+
+func TestNextWeekday(t *testing.T) {
+	wed := int64(1705449600) // 2024-01-17 Wednesday 00:00 UTC
+	day := int64(86400)
+	pst := time.FixedZone("PST", -8*3600)
+
+	tests := []struct {
+		name     string
+		from     int64
+		target   time.Weekday
+		loc      *time.Location
+		expected int64
+	}{
+		{"next monday", wed + 13*3600, time.Monday, time.UTC, wed + 5*day},
+		{"next thursday", wed, time.Thursday, time.UTC, wed + day},
+		{"same day is next week", wed + 20*3600, time.Wednesday, time.UTC, wed + 7*day},
+		{"next sunday", wed, time.Sunday, time.UTC, wed + 4*day},
+		// Wednesday 00:00 UTC is Tuesday 16:00 in PST.
+		{"zone shifts the day", wed, time.Wednesday, pst, wed + 8*3600},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NextWeekday(tt.from, tt.target, tt.loc); got != tt.expected {
+				t.Errorf("NextWeekday(%d, %s, %s) = %d, want %d", tt.from, tt.target, tt.loc, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDurationClock(t *testing.T) {
+	tests := []struct {
+		seconds  int
+		expected string
+	}{
+		{0, "0:00"},
+		{9, "0:09"},
+		{65, "1:05"},
+		{3599, "59:59"},
+		{3600, "1:00:00"},
+		{3725, "1:02:05"},
+		{360000, "100:00:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if got := DurationClock(tt.seconds); got != tt.expected {
+				t.Errorf("DurationClock(%d) = %q, want %q", tt.seconds, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTimeUntil(t *testing.T) {
+	now := int64(1704067200) // 2024-01-01 00:00:00 UTC
+
+	tests := []struct {
+		target   int64
+		expected string
+	}{
+		{now + 3*3600, "in 3 hours"},
+		{now + 120, "in 2 minutes"},
+		{now + 10, "now"},
+		{now, "now"},
+		{now - 10, "now"},
+		{now - 3600, "passed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if got := TimeUntil(tt.target, now); got != tt.expected {
+				t.Errorf("TimeUntil(%d, %d) = %q, want %q", tt.target, now, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRoundTime(t *testing.T) {
+	day := int64(1705276800) // 2024-01-15 00:00 UTC
+
+	tests := []struct {
+		name     string
+		got      int64
+		expected int64
+	}{
+		{"round down to hour", RoundTime(day+3600+1799, 3600), day + 3600},
+		{"round up to hour", RoundTime(day+3600+1800, 3600), day + 7200},
+		{"round exact hour", RoundTime(day+7200, 3600), day + 7200},
+		{"floor to day", FloorTime(day+86399, 86400), day},
+		{"floor exact day", FloorTime(day, 86400), day},
+		{"ceil to day", CeilTime(day+1, 86400), day + 86400},
+		{"ceil exact day", CeilTime(day, 86400), day},
+		{"floor before epoch", FloorTime(-1, 86400), -86400},
+		{"round 15 minutes", RoundTime(day+8*60, 900), day + 900},
+		{"floor zero unit", FloorTime(day+123, 0), day + 123},
+		{"ceil zero unit", CeilTime(day+123, 0), day + 123},
+		{"round zero unit", RoundTime(day+123, 0), day + 123},
+		{"round negative unit", RoundTime(day+123, -60), day + 123},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.expected {
+				t.Errorf("got %d, want %d", tt.got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBCEYears(t *testing.T) {
+	ref := int64(1705276800)                                             // 2024-01-15 Monday 00:00 UTC
+	ides := time.Date(-43, time.March, 15, 12, 0, 0, 0, time.UTC).Unix() // 44 BCE
+	yearZero := time.Date(0, time.January, 1, 0, 0, 0, 0, time.UTC).Unix()
+	yearOne := time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC).Unix()
+
+	tests := []struct {
+		name     string
+		got      string
+		expected string
+	}{
+		{"44 bce", HumanDate(ides, ref), "March 15, 44 BCE"},
+		{"1 bce", HumanDate(yearZero, ref), "January 1, 1 BCE"},
+		{"1 ce", HumanDate(yearOne, ref), "January 1, 1"},
+		{"bce same month", DateRange(ides, ides+2*86400), "March 15–17, 44 BCE"},
+		{"across era", DateRange(yearZero, yearOne), "January 1, 1 BCE – January 1, 1"},
+		{"bce to today", DateRange(ides, ref), "March 15, 44 BCE – January 15, 2024"},
+		{"relative future far", HumanDateWith(ref, ides, DateOptions{RelativeFuture: true}), "In 2068 years"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.expected {
+				t.Errorf("got %q, want %q", tt.got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDateRangeDistant(t *testing.T) {
+	tests := []struct {
+		name     string
+		start    int64
+		end      int64
+		opts     DateOptions
+		expected string
+	}{
+		{"decades", 920592000, 1705276800, DateOptions{}, "March 5, 1999 – January 15, 2024"},
+		{"centuries", -2209075200, 1705276800, DateOptions{}, "December 31, 1899 – January 15, 2024"},
+		{"century boundary", -2209075200, 946684800, DateOptions{}, "December 31, 1899 – January 1, 2000"},
+		{"reversed", 1705276800, 920592000, DateOptions{}, "March 5, 1999 – January 15, 2024"},
+		{"compact decades", 920592000, 1705276800, DateOptions{Compact: true}, "Mar 5, 1999 – Jan 15, 2024"},
+		{"compact centuries", -2209075200, 1705276800, DateOptions{Compact: true}, "Dec 31, 1899 – Jan 15, 2024"},
+		{"compact same year", 1705276800, 1707955200, DateOptions{Compact: true}, "Jan 15 – Feb 15, 2024"},
+		{"compact same month", 1705276800, 1705881600, DateOptions{Compact: true}, "Jan 15–22, 2024"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DateRangeWith(tt.start, tt.end, tt.opts); got != tt.expected {
+				t.Errorf("DateRangeWith(%d, %d, %+v) = %q, want %q", tt.start, tt.end, tt.opts, got, tt.expected)
+			}
+		})
+	}
+
+	// Tables without abbreviations fall back to full month names.
+	fr, _ := LookupLocale("fr")
+	opts := DateOptions{Compact: true, Names: &fr.Names}
+	if got := DateRangeWith(920592000, 1705276800, opts); got != "mars 5, 1999 – janvier 15, 2024" {
+		t.Errorf("DateRangeWith = %q, want %q", got, "mars 5, 1999 – janvier 15, 2024")
+	}
+}
+
+func TestCase(t *testing.T) {
+	ref := int64(1705276800) // 2024-01-15 Monday 00:00 UTC
+
+	tests := []struct {
+		name     string
+		got      string
+		expected string
+	}{
+		{"time ago default", TimeAgoWith(ref-10800, ref, TimeAgoOptions{}), "3 hours ago"},
+		{"time ago lower", TimeAgoWith(ref-10800, ref, TimeAgoOptions{Case: CaseLower}), "3 hours ago"},
+		{"time ago sentence", TimeAgoWith(ref-10800, ref, TimeAgoOptions{Case: CaseSentence}), "3 hours ago"},
+		{"time ago title", TimeAgoWith(ref-10800, ref, TimeAgoOptions{Case: CaseTitle}), "3 Hours Ago"},
+		{"future sentence", TimeAgoWith(ref+10800, ref, TimeAgoOptions{Case: CaseSentence}), "In 3 hours"},
+		{"just now sentence", TimeAgoWith(ref, ref, TimeAgoOptions{Case: CaseSentence}), "Just now"},
+		{"article title", TimeAgoWith(ref-3600, ref, TimeAgoOptions{UseArticles: true, Case: CaseTitle}), "An Hour Ago"},
+		{"human date default", HumanDateWith(ref, ref, DateOptions{}), "Today"},
+		{"human date lower", HumanDateWith(ref, ref, DateOptions{Case: CaseLower}), "today"},
+		{"weekday lower", HumanDateWith(ref-2*86400, ref, DateOptions{Case: CaseLower}), "last Saturday"},
+		{"weekday title", HumanDateWith(ref-2*86400, ref, DateOptions{Case: CaseTitle}), "Last Saturday"},
+		{"relative future title", HumanDateWith(ref+14*86400, ref, DateOptions{RelativeFuture: true, Case: CaseTitle}), "In 2 Weeks"},
+		{"date time lower", HumanDateTime(ref-86400+32400, ref, DateOptions{Case: CaseLower}), "yesterday at 9:00 AM"},
+		{"far date lower", HumanDateWith(1704412800, 1717200000, DateOptions{Case: CaseLower}), "January 5"},
+		{"far year lower", HumanDateWith(1704412800, 1740000000, DateOptions{Case: CaseLower}), "January 5, 2024"},
+		{"far date dmy lower", HumanDateWith(1704412800, 1717200000, DateOptions{Case: CaseLower, Order: DMY}), "5 January"},
+		{"short weekday lower", HumanDateWith(ref-2*86400, ref, DateOptions{Case: CaseLower, ShortWeekdays: true}), "last Sat"},
+		{"compact date title", HumanDateWith(1704412800, 1740000000, DateOptions{Case: CaseTitle, Compact: true}), "Jan 5, 2024"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.expected {
+				t.Errorf("got %q, want %q", tt.got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHideCurrentYear(t *testing.T) {
+	mar5 := int64(1709596800)  // 2024-03-05
+	apr10 := int64(1712707200) // 2024-04-10
+	ref := int64(1717200000)   // 2024-06-01
+	hide := DateOptions{HideCurrentYear: true, Reference: ref}
+
+	tests := []struct {
+		name     string
+		start    int64
+		end      int64
+		opts     DateOptions
+		expected string
+	}{
+		{"same year shown", mar5, apr10, DateOptions{}, "March 5 – April 10, 2024"},
+		{"same year hidden", mar5, apr10, hide, "March 5 – April 10"},
+		{"same month shown", mar5, mar5 + 5*86400, DateOptions{}, "March 5–10, 2024"},
+		{"same month hidden", mar5, mar5 + 5*86400, hide, "March 5–10"},
+		{"same day hidden", mar5, mar5 + 3600, hide, "March 5"},
+		{"other year kept", mar5 - 366*86400, apr10 - 366*86400, hide, "March 5 – April 10, 2023"},
+		{"cross year kept", 1677974400, apr10, hide, "March 5, 2023 – April 10, 2024"},
+		{"no reference", mar5, apr10, DateOptions{HideCurrentYear: true}, "March 5 – April 10, 2024"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DateRangeWith(tt.start, tt.end, tt.opts); got != tt.expected {
+				t.Errorf("DateRangeWith(%d, %d, %+v) = %q, want %q", tt.start, tt.end, tt.opts, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsTodayYesterdayTomorrow(t *testing.T) {
+	pst := time.FixedZone("PST", -8*3600)
+	midnight := int64(1705305600) // 2024-01-15 00:00 PST (08:00 UTC)
+
+	tests := []struct {
+		name      string
+		timestamp int64
+		now       int64
+		loc       *time.Location
+		today     bool
+		yesterday bool
+		tomorrow  bool
+	}{
+		{"same instant", midnight, midnight, pst, true, false, false},
+		{"second before midnight", midnight - 1, midnight, pst, false, true, false},
+		{"later same day", midnight + 86399, midnight, pst, true, false, false},
+		{"next midnight", midnight + 86400, midnight, pst, false, false, true},
+		{"now just before midnight", midnight, midnight - 1, pst, false, false, true},
+		{"two days ago", midnight - 86401, midnight, pst, false, false, false},
+		{"utc still today", midnight - 1, midnight, time.UTC, true, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsToday(tt.timestamp, tt.now, tt.loc); got != tt.today {
+				t.Errorf("IsToday = %v, want %v", got, tt.today)
+			}
+			if got := IsYesterday(tt.timestamp, tt.now, tt.loc); got != tt.yesterday {
+				t.Errorf("IsYesterday = %v, want %v", got, tt.yesterday)
+			}
+			if got := IsTomorrow(tt.timestamp, tt.now, tt.loc); got != tt.tomorrow {
+				t.Errorf("IsTomorrow = %v, want %v", got, tt.tomorrow)
+			}
+		})
+	}
+}
+
+func TestParseDurationDetailed(t *testing.T) {
+	tests := []struct {
+		input    string
+		seconds  int
+		unparsed string
+		err      error
+	}{
+		{"2 hours and banana", 7200, "banana", errUnrecognized},
+		{"1h 30m xyz", 5400, "xyz", errUnrecognized},
+		{"3 days, 4 hours, and lots", 273600, "lots", errUnrecognized},
+		{"some 5 minutes here", 300, "some here", errUnrecognized},
+		{"5 parsecs and 10 minutes", 600, "5 parsecs", errUnknownUnit},
+		{"hello", 0, "hello", errUnrecognized},
+		{"42", 0, "42", errBareNumber},
+		{"-5m", 0, "-5m", errNegative},
+		{"", 0, "", errEmpty},
+		{"2 hours and a banana", 7200, "a banana", errUnknownUnit},
+		{"two hours and twelve bananas", 7200, "twelve bananas", errUnknownUnit},
+		{"an hour and a half, then more", 5400, "then more", errUnrecognized},
+		{"Half an hour  plus  ten minutes", 2400, "plus", errUnrecognized},
+		{"banana and", 0, "banana", errUnrecognized},
+		{"1:30 junk", 5400, "junk", errUnrecognized},
+		{"1h30m", 5400, "", nil},
+		{"an hour and a half", 5400, "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			seconds, unparsed, err := ParseDurationDetailed(tt.input)
+			if seconds != tt.seconds || unparsed != tt.unparsed || err != tt.err {
+				t.Errorf("ParseDurationDetailed(%q) = %d, %q, %v; want %d, %q, %v",
+					tt.input, seconds, unparsed, err, tt.seconds, tt.unparsed, tt.err)
+			}
+		})
+	}
+}
+
+func TestTimeAgoAuto(t *testing.T) {
+	ref := int64(1705276800)
+
+	tests := []struct {
+		name     string
+		diff     int64
+		round    RoundMode
+		expected string
+	}{
+		{"89 minutes floor", 89 * 60, Floor, "1 hour ago"},
+		{"89 minutes nearest", 89 * 60, Nearest, "1 hour ago"},
+		{"89 minutes ceil", 89 * 60, Ceil, "2 hours ago"},
+		{"90 minutes floor", 90 * 60, Floor, "1 hour ago"},
+		{"90 minutes nearest", 90 * 60, Nearest, "2 hours ago"},
+		{"exact hour ceil", 3600, Ceil, "1 hour ago"},
+		{"59m30s nearest carries", 3570, Nearest, "1 hour ago"},
+		{"59m30s floor", 3570, Floor, "59 minutes ago"},
+		{"36 hours ceil", 36 * 3600, Ceil, "2 days ago"},
+		{"future ceil", -61, Ceil, "in 2 minutes"},
+		{"future floor", -61, Floor, "in 1 minute"},
+		{"seconds", 45, Nearest, "45 seconds ago"},
+		{"zero", 0, Ceil, "just now"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TimeAgoAuto(ref-tt.diff, ref, tt.round); got != tt.expected {
+				t.Errorf("TimeAgoAuto(%d) = %q, want %q", tt.diff, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDateOrder(t *testing.T) {
+	mar5 := int64(1709596800)  // 2024-03-05 00:00 UTC
+	apr10 := int64(1712707200) // 2024-04-10 00:00 UTC
+	later := int64(1740000000) // 2025-02-19
+	fr, _ := LookupLocale("fr")
+	dmy := DateOptions{Order: DMY}
+
+	tests := []struct {
+		name     string
+		got      string
+		expected string
+	}{
+		{"human date mdy", HumanDateWith(mar5, later, DateOptions{Order: MDY}), "March 5, 2024"},
+		{"human date dmy", HumanDateWith(mar5, later, dmy), "5 March 2024"},
+		{"human date dmy same year", HumanDateWith(mar5, apr10+86400*30, dmy), "5 March"},
+		{"human date dmy french", HumanDateWith(mar5, later, DateOptions{Order: DMY, Names: &fr.Names}), "5 mars 2024"},
+		{"human date dmy ordinal", HumanDateWith(mar5, later, DateOptions{Order: DMY, Ordinal: true}), "5th March 2024"},
+		{"relative unaffected", HumanDateWith(mar5, mar5, dmy), "Today"},
+		{"range same day", DateRangeWith(mar5, mar5+3600, dmy), "5 March 2024"},
+		{"range same month", DateRangeWith(mar5, mar5+5*86400, dmy), "5–10 March 2024"},
+		{"range same year", DateRangeWith(mar5, apr10, dmy), "5 March – 10 April 2024"},
+		{"range different years", DateRangeWith(920592000, 1705276800, dmy), "5 March 1999 – 15 January 2024"},
+		{"range dmy french", DateRangeWith(mar5, apr10, DateOptions{Order: DMY, Names: &fr.Names}), "5 mars – 10 avril 2024"},
+		{"range dmy hidden year", DateRangeWith(mar5, apr10, DateOptions{Order: DMY, HideCurrentYear: true, Reference: apr10}), "5 March – 10 April"},
+		{"date time dmy", HumanDateTime(mar5+32400, later, dmy), "5 March 2024, 9:00 AM"},
+		{"date time range dmy", DateTimeRangeWith(mar5+32400, mar5+64800, dmy), "5 March 2024, 9:00 AM – 6:00 PM"},
+		{"date time range dmy days", DateTimeRangeWith(mar5+32400, apr10+64800, dmy), "5 March, 9:00 AM – 10 April 2024, 6:00 PM"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.expected {
+				t.Errorf("got %q, want %q", tt.got, tt.expected)
+			}
+		})
+	}
+}